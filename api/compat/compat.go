@@ -0,0 +1,171 @@
+// Package compat serves the restcountries.com v3.1 API with its response shapes and status codes:
+// every country route answers with an array of records as stored in the countries file, no
+// match is 404, malformed codes are 400 and /all requires fields. Matching itself uses the same
+// lookups as /v1.
+package compat
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+// maxAllFields is the number of fields /all accepts.
+const maxAllFields = 10
+
+// errorBody is the v3.1 error shape.
+type errorBody struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// Register adds the v3.1 routes to group, conventionally mounted at /v3.1.
+func Register(group *gin.RouterGroup) {
+	group.GET("/all", all)
+	group.GET("/name/:name", byName)
+	group.GET("/alpha", byCodes)
+	group.GET("/alpha/:code", byCode)
+	group.GET("/currency/:currency", search("currency"))
+	group.GET("/demonym/:demonym", search("demonym"))
+	group.GET("/lang/:language", search("language"))
+	group.GET("/capital/:capital", search("capital"))
+	group.GET("/region/:region", search("region"))
+	group.GET("/subregion/:subregion", search("subregion"))
+	group.GET("/translation/:translation", search("translation"))
+	group.GET("/independent", independent)
+}
+
+// all handles /all, which only answers with the requested fields of every country.
+func all(c *gin.Context) {
+	fields := parseFields(c.Query("fields"))
+	if len(fields) == 0 {
+		respondError(c, http.StatusBadRequest, "'fields' query not specified")
+		return
+	}
+	if len(fields) > maxAllFields {
+		respondError(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+	respondCountries(c, v1.Countries)
+}
+
+// byName handles /name/{name}; fullText=true matches the whole common or official name.
+func byName(c *gin.Context) {
+	key := "name"
+	if strings.EqualFold(c.Query("fullText"), "true") {
+		key = "fullName"
+	}
+	searchCountries(c, key, c.Param("name"))
+}
+
+// byCode handles /alpha/{code}, answering with an array of the one matching country.
+func byCode(c *gin.Context) {
+	code := c.Param("code")
+	if len(code) < 2 || len(code) > 3 {
+		respondError(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+	country, ok := v1.FindCountry(code)
+	if !ok {
+		respondError(c, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+	respondCountries(c, []v1.Country{country})
+}
+
+// byCodes handles /alpha?codes=, answering with the countries of the known codes in request
+// order. A lone code longer than 3 characters is rejected as v3.1 does.
+func byCodes(c *gin.Context) {
+	codes := c.Query("codes")
+	if len(codes) < 2 || len(codes) > 3 && !strings.Contains(codes, ",") {
+		respondError(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+	var countries []v1.Country
+	seen := make(map[string]bool)
+	for _, code := range strings.Split(codes, ",") {
+		country, ok := v1.FindCountry(strings.TrimSpace(code))
+		if !ok || seen[country.CCA3] {
+			continue
+		}
+		seen[country.CCA3] = true
+		countries = append(countries, country)
+	}
+	respondCountries(c, countries)
+}
+
+// independent handles /independent. Without status it lists independent countries; any value
+// other than "true" lists the others.
+func independent(c *gin.Context) {
+	status := c.DefaultQuery("status", "true")
+	if strings.EqualFold(status, "true") {
+		status = "true"
+	} else {
+		status = "false"
+	}
+	searchCountries(c, "independent", status)
+}
+
+// search returns the handler of a search route whose path parameter is named after its
+// v1.SearchCountries filter key.
+func search(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		searchCountries(c, key, c.Param(key))
+	}
+}
+
+// searchCountries answers with the countries matching one v1.SearchCountries filter.
+func searchCountries(c *gin.Context, key, value string) {
+	countries, err := v1.SearchCountries(c.Request.Context(), map[string]string{key: value})
+	if err != nil {
+		respondError(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+	respondCountries(c, countries)
+}
+
+// respondCountries writes the stored records of countries, reduced to the top-level members
+// named in fields when given. Unknown field names are ignored, as in v3.1. No country is 404.
+func respondCountries(c *gin.Context, countries []v1.Country) {
+	if len(countries) == 0 {
+		respondError(c, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+	fields := parseFields(c.Query("fields"))
+
+	result := make([]map[string]json.RawMessage, 0, len(countries))
+	for _, country := range countries {
+		record := v1.CountryRecords[country.CCA3]
+		if len(fields) > 0 {
+			selected := make(map[string]json.RawMessage, len(fields))
+			for _, field := range fields {
+				if value, ok := record[field]; ok {
+					selected[field] = value
+				}
+			}
+			record = selected
+		}
+		result = append(result, record)
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// parseFields splits the fields parameter, dropping blank entries.
+func parseFields(fields string) []string {
+	var names []string
+	for _, field := range strings.Split(fields, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			names = append(names, field)
+		}
+	}
+	return names
+}
+
+// respondError writes an error in the v3.1 shape.
+func respondError(c *gin.Context, status int, message string) {
+	c.JSON(status, errorBody{Status: status, Message: message})
+}
@@ -0,0 +1,120 @@
+package compat
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+var (
+	loadOnce sync.Once
+	loadErr  error
+)
+
+// loadDataset loads the countries and languages files the compat routes answer from, once per
+// test binary.
+func loadDataset(t *testing.T) {
+	t.Helper()
+	loadOnce.Do(func() {
+		if loadErr = v1.LoadCountriesSafe("../../data/countries.json"); loadErr == nil {
+			loadErr = v1.LoadLanguagesSafe("../../data/languages.json")
+		}
+	})
+	if loadErr != nil {
+		t.Fatal(loadErr)
+	}
+}
+
+// compatRouter serves the compat routes under /v3.1.
+func compatRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Register(router.Group("/v3.1"))
+	return router
+}
+
+// get serves target and returns the status and raw body.
+func get(router *gin.Engine, target string) (int, []byte) {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w.Code, w.Body.Bytes()
+}
+
+func TestAlphaCodeAnswersWithArray(t *testing.T) {
+	loadDataset(t)
+	router := compatRouter()
+
+	for _, target := range []string{"/v3.1/alpha/de?fields=cca3", "/v3.1/alpha/DEU?fields=cca3", "/v3.1/alpha/276?fields=cca3"} {
+		status, body := get(router, target)
+		if status != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", target, status, body)
+		}
+		var countries []map[string]string
+		if err := json.Unmarshal(body, &countries); err != nil {
+			t.Fatalf("%s: %v: %s", target, err, body)
+		}
+		if len(countries) != 1 || countries[0]["cca3"] != "DEU" || len(countries[0]) != 1 {
+			t.Errorf("%s: body %s, want [{\"cca3\":\"DEU\"}]", target, body)
+		}
+	}
+}
+
+func TestErrorsUseV31Shape(t *testing.T) {
+	loadDataset(t)
+	router := compatRouter()
+
+	for _, tc := range []struct {
+		target  string
+		status  int
+		message string
+	}{
+		{"/v3.1/alpha/zzz", http.StatusNotFound, "Not Found"},
+		{"/v3.1/name/atlantis", http.StatusNotFound, "Not Found"},
+		{"/v3.1/alpha/toolong", http.StatusBadRequest, "Bad Request"},
+		{"/v3.1/alpha/d", http.StatusBadRequest, "Bad Request"},
+		{"/v3.1/all", http.StatusBadRequest, "'fields' query not specified"},
+		{"/v3.1/all?fields=" + strings.Repeat("cca3,", maxAllFields) + "cca2", http.StatusBadRequest, "Bad Request"},
+	} {
+		status, body := get(router, tc.target)
+		if status != tc.status {
+			t.Errorf("%s: status %d, want %d", tc.target, status, tc.status)
+			continue
+		}
+		var got errorBody
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("%s: %v: %s", tc.target, err, body)
+		}
+		if got != (errorBody{Status: tc.status, Message: tc.message}) {
+			t.Errorf("%s: body %s, want status %d and message %q", tc.target, body, tc.status, tc.message)
+		}
+	}
+}
+
+func TestAllAnswersWithRequestedFields(t *testing.T) {
+	loadDataset(t)
+	router := compatRouter()
+
+	status, body := get(router, "/v3.1/all?fields=cca3,independent")
+	if status != http.StatusOK {
+		t.Fatalf("status %d: %s", status, body)
+	}
+	var countries []map[string]json.RawMessage
+	if err := json.Unmarshal(body, &countries); err != nil {
+		t.Fatal(err)
+	}
+	if len(countries) != len(v1.Countries) {
+		t.Errorf("%d countries, want %d", len(countries), len(v1.Countries))
+	}
+	for _, country := range countries {
+		if len(country) != 2 || country["cca3"] == nil || country["independent"] == nil {
+			t.Fatalf("country %v, want only cca3 and independent", country)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package compat
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// record records the pending fixtures, and re-records the recorded ones, from upstream before
+// comparing against them.
+var record = flag.Bool("record", false, "record the conformance fixtures from -upstream")
+
+// upstream is the restcountries deployment fixtures are recorded from.
+var upstream = flag.String("upstream", "https://restcountries.com", "base URL fixtures are recorded from")
+
+const (
+	// fixtureDir holds the fixtures recorded from upstream, each compared by TestConformance.
+	fixtureDir = "testdata/conformance"
+	// pendingDir holds requests awaiting their first recording; -record moves them to fixtureDir.
+	pendingDir = "testdata/conformance/pending"
+)
+
+// fixture is a request and the restcountries response recorded for it. Only responses recorded
+// from upstream are committed; requests not recorded yet wait in pendingDir.
+type fixture struct {
+	Description string `json:"description"`
+	Source      string `json:"source,omitempty"`
+	Request     string `json:"request"`
+	Status      int    `json:"status,omitempty"`
+	// Unordered compares array bodies regardless of element order.
+	Unordered bool `json:"unordered,omitempty"`
+	// Ignore lists dotted member paths left out of the comparison, for values expected to
+	// drift between dataset versions; within arrays they apply to every element.
+	Ignore []string        `json:"ignore,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+func TestConformance(t *testing.T) {
+	loadDataset(t)
+	router := compatRouter()
+
+	pending, err := filepath.Glob(filepath.Join(pendingDir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *record {
+		for _, file := range pending {
+			fx := loadFixture(t, file)
+			recordFixture(t, filepath.Join(fixtureDir, filepath.Base(file)), &fx)
+			if err := os.Remove(file); err != nil {
+				t.Fatal(err)
+			}
+		}
+		pending = nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(fixtureDir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without recorded fixtures nothing is compared, which must not pass as conformance
+	if len(files) == 0 {
+		t.Fatalf("no recorded fixtures in %s (%d pending in %s); run go test ./api/compat -record where restcountries.com is reachable",
+			fixtureDir, len(pending), pendingDir)
+	}
+	for _, file := range files {
+		t.Run(strings.TrimSuffix(filepath.Base(file), ".json"), func(t *testing.T) {
+			fx := loadFixture(t, file)
+			if *record {
+				recordFixture(t, file, &fx)
+			}
+			if fx.Body == nil || fx.Status == 0 {
+				t.Fatalf("%s has no recorded response; record it with -record or move it to %s", file, pendingDir)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fx.Request, nil))
+			if w.Code != fx.Status {
+				t.Errorf("%s: status %d, upstream %d", fx.Request, w.Code, fx.Status)
+			}
+			got := normalize(t, w.Body.Bytes(), fx)
+			want := normalize(t, fx.Body, fx)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: body differs from upstream\n got: %s\nwant: %s", fx.Request, abbreviate(got), abbreviate(want))
+			}
+		})
+	}
+}
+
+// loadFixture reads one fixture file.
+func loadFixture(t *testing.T, file string) fixture {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fx fixture
+	if err := json.Unmarshal(data, &fx); err != nil {
+		t.Fatalf("%s: %v", file, err)
+	}
+	return fx
+}
+
+// recordFixture replaces the status and body of fx with the upstream response and saves it to file.
+func recordFixture(t *testing.T, file string, fx *fixture) {
+	t.Helper()
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(*upstream + fx.Request)
+	if err != nil {
+		t.Fatalf("recording %s: %v", fx.Request, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("recording %s: %v", fx.Request, err)
+	}
+	if !json.Valid(body) {
+		t.Fatalf("recording %s: response is not JSON", fx.Request)
+	}
+
+	fx.Status = resp.StatusCode
+	fx.Body = body
+	fx.Source = fmt.Sprintf("recorded from %s on %s", *upstream, time.Now().UTC().Format("2006-01-02"))
+	data, err := json.MarshalIndent(fx, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// normalize decodes a body and drops the ignored paths; unordered arrays are sorted by the
+// encoding of their elements.
+func normalize(t *testing.T, body []byte, fx fixture) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		t.Fatalf("%s: invalid JSON body: %v", fx.Request, err)
+	}
+	for _, path := range fx.Ignore {
+		removePath(v, strings.Split(path, "."))
+	}
+	if list, ok := v.([]interface{}); ok && fx.Unordered {
+		keys := make([]string, len(list))
+		for i, element := range list {
+			encoded, _ := json.Marshal(element)
+			keys[i] = string(encoded)
+		}
+		sort.Sort(byKey{keys, list})
+	}
+	return v
+}
+
+// byKey sorts values by their parallel keys.
+type byKey struct {
+	keys   []string
+	values []interface{}
+}
+
+func (s byKey) Len() int           { return len(s.keys) }
+func (s byKey) Less(i, j int) bool { return s.keys[i] < s.keys[j] }
+func (s byKey) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+// removePath deletes a dotted member path from v, descending into every array element.
+func removePath(v interface{}, path []string) {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, element := range v {
+			removePath(element, path)
+		}
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(v, path[0])
+			return
+		}
+		removePath(v[path[0]], path[1:])
+	}
+}
+
+// abbreviate encodes v for a failure message, cut to a readable length.
+func abbreviate(v interface{}) string {
+	encoded, _ := json.Marshal(v)
+	if len(encoded) > 600 {
+		return string(encoded[:600]) + "..."
+	}
+	return string(encoded)
+}
@@ -0,0 +1,166 @@
+// auth.go contains the API key authentication middleware honoring the dapi-key header.
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+// APIKeyHeader is the request header carrying the API key.
+const APIKeyHeader = "dapi-key"
+
+// APIKeyLabelKey is the gin context key under which the authenticated key's label is stored.
+const APIKeyLabelKey = "apiKeyLabel"
+
+// APIKeyTierKey is the gin context key under which the authenticated key's rate limit tier is stored.
+const APIKeyTierKey = "apiKeyTier"
+
+// APIKey describes one entry of the keys file. Only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	Label   string   `json:"label"`
+	Hash    string   `json:"hash"`
+	Enabled bool     `json:"enabled"`
+	Routes  []string `json:"routes,omitempty"`
+	Tier    string   `json:"tier,omitempty"`
+}
+
+// keysFile is the on-disk layout of the keys file.
+type keysFile struct {
+	Keys []APIKey `json:"keys"`
+}
+
+// KeyStore holds the API keys loaded from a keys file and can be reloaded at runtime.
+type KeyStore struct {
+	filename string
+
+	mu   sync.RWMutex
+	keys map[string]APIKey // keyed by lowercase hex SHA-256 hash
+}
+
+// NewKeyStore loads the keys file and returns a store backed by it.
+func NewKeyStore(filename string) (*KeyStore, error) {
+	store := &KeyStore{filename: filename}
+	if err := store.Reload(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Reload re-reads the keys file. On error the previously loaded keys stay in effect.
+func (s *KeyStore) Reload() error {
+	data, err := os.ReadFile(s.filename)
+	if err != nil {
+		return fmt.Errorf("failed to read keys file: %w", err)
+	}
+
+	var file keysFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse keys file: %w", err)
+	}
+
+	keys := make(map[string]APIKey, len(file.Keys))
+	labels := make(map[string]int, len(file.Keys))
+	for i, key := range file.Keys {
+		hash := strings.ToLower(strings.TrimPrefix(key.Hash, "sha256:"))
+		if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("invalid hash for key %d (%s): must be a hex SHA-256 digest", i, key.Label)
+		}
+		if key.Label == "" {
+			return fmt.Errorf("missing label for key %d", i)
+		}
+		// Labels name the rate-limit bucket and metrics series of a key, so they must be unique
+		if first, ok := labels[key.Label]; ok {
+			return fmt.Errorf("duplicate label %q for keys %d and %d", key.Label, first, i)
+		}
+		labels[key.Label] = i
+		keys[hash] = key
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+	return nil
+}
+
+// Lookup finds the entry matching the given plaintext key.
+func (s *KeyStore) Lookup(key string) (APIKey, bool) {
+	sum := sha256.Sum256([]byte(key))
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.keys[hex.EncodeToString(sum[:])]
+	return entry, ok
+}
+
+// Identify returns the label and tier of key if it is known and enabled. Missing, unknown and
+// disabled keys, and any key when s is nil, identify as anonymous with empty strings, so rate
+// limits charge them to the client IP.
+func (s *KeyStore) Identify(key string) (label, tier string) {
+	if s == nil || key == "" {
+		return "", ""
+	}
+	entry, ok := s.Lookup(key)
+	if !ok || !entry.Enabled {
+		return "", ""
+	}
+	return entry.Label, entry.Tier
+}
+
+// Allows reports whether the key may access the given route template, or gRPC method such as
+// "/gcr.v1.CountryService/Get". An empty route list allows every route; a trailing "*" matches
+// any route with that prefix (e.g. "/v1/alpha*").
+func (k APIKey) Allows(route string) bool {
+	if len(k.Routes) == 0 {
+		return true
+	}
+	for _, allowed := range k.Routes {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok {
+			if strings.HasPrefix(route, prefix) {
+				return true
+			}
+		} else if allowed == route {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyAuth validates the dapi-key header against the store. Requests without a key or with an
+// unknown key get 401; disabled keys and keys not allowed on the route get 403.
+func APIKeyAuth(store *KeyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			v1.AbortWithProblem(c, http.StatusUnauthorized, v1.CodeMissingAPIKey, APIKeyHeader, "Missing API key in "+APIKeyHeader+" header")
+			return
+		}
+
+		entry, ok := store.Lookup(key)
+		if !ok {
+			v1.AbortWithProblem(c, http.StatusUnauthorized, v1.CodeInvalidAPIKey, APIKeyHeader, "Invalid API key")
+			return
+		}
+		if !entry.Enabled {
+			v1.AbortWithProblem(c, http.StatusForbidden, v1.CodeAPIKeyDisabled, APIKeyHeader, "API key is disabled")
+			return
+		}
+		if !entry.Allows(c.FullPath()) {
+			v1.AbortWithProblem(c, http.StatusForbidden, v1.CodeRouteNotAllowed, "", "API key is not allowed to access this route")
+			return
+		}
+
+		c.Set(APIKeyLabelKey, entry.Label)
+		c.Set(APIKeyTierKey, entry.Tier)
+		c.Next()
+	}
+}
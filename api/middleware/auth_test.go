@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+// hashKey returns the keys file hash of key.
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeKeys writes a keys file with the enabled key "secret" on every route, "alpha" limited
+// to the /v1/alpha routes and the disabled key "revoked", and returns its path.
+func writeKeys(t *testing.T) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "keys.json")
+	keys := `{"keys": [
+		{"label": "app", "hash": "` + hashKey("secret") + `", "enabled": true, "tier": "pro"},
+		{"label": "alpha", "hash": "sha256:` + hashKey("alpha") + `", "enabled": true, "routes": ["/v1/alpha*"]},
+		{"label": "old", "hash": "` + hashKey("revoked") + `", "enabled": false}
+	]}`
+	if err := os.WriteFile(file, []byte(keys), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// authRouter serves /v1/alpha/:code and /v1/all behind APIKeyAuth, answering with the
+// authenticated label and tier.
+func authRouter(store *KeyStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(APIKeyAuth(store))
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"label": c.GetString(APIKeyLabelKey), "tier": c.GetString(APIKeyTierKey)})
+	}
+	router.GET("/v1/alpha/:code", handler)
+	router.GET("/v1/all", handler)
+	return router
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	store, err := NewKeyStore(writeKeys(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := authRouter(store)
+
+	for _, tc := range []struct {
+		name, key, path string
+		status          int
+		code            v1.ErrorCode
+		label           string
+	}{
+		{"missing key", "", "/v1/all", http.StatusUnauthorized, v1.CodeMissingAPIKey, ""},
+		{"unknown key", "guess", "/v1/all", http.StatusUnauthorized, v1.CodeInvalidAPIKey, ""},
+		{"disabled key", "revoked", "/v1/all", http.StatusForbidden, v1.CodeAPIKeyDisabled, ""},
+		{"route not allowed", "alpha", "/v1/all", http.StatusForbidden, v1.CodeRouteNotAllowed, ""},
+		{"wildcard route", "alpha", "/v1/alpha/DE", http.StatusOK, "", "alpha"},
+		{"every route", "secret", "/v1/all", http.StatusOK, "", "app"},
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		if tc.key != "" {
+			req.Header.Set(APIKeyHeader, tc.key)
+		}
+		router.ServeHTTP(w, req)
+
+		if w.Code != tc.status {
+			t.Errorf("%s: status %d, want %d", tc.name, w.Code, tc.status)
+			continue
+		}
+		if tc.status != http.StatusOK {
+			var problem v1.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+			if problem.Code != tc.code {
+				t.Errorf("%s: problem code %s, want %s", tc.name, problem.Code, tc.code)
+			}
+			continue
+		}
+		var body map[string]string
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if body["label"] != tc.label {
+			t.Errorf("%s: label %q, want %q", tc.name, body["label"], tc.label)
+		}
+	}
+}
+
+func TestAPIKeyAllows(t *testing.T) {
+	key := APIKey{Routes: []string{"/v1/alpha*", "/v1/all", "/gcr.v1.CountryService/*"}}
+	for route, want := range map[string]bool{
+		"/v1/alpha":                      true,
+		"/v1/alpha/:code":                true,
+		"/v1/alpha/:code/memberships":    true,
+		"/v1/all":                        true,
+		"/v1/all/":                       false,
+		"/v1/countries":                  false,
+		"/v1/alp":                        false,
+		"/gcr.v1.CountryService/Get":     true,
+		"/gcr.v1.CountryService":         false,
+		"/grpc.health.v1.Health/Check":   false,
+		"/v1/name/:name":                 false,
+		"/v1/ALPHA/:code":                false,
+		"/v1/languages/:code":            false,
+		"/v1/regions/:region/subregions": false,
+	} {
+		if got := key.Allows(route); got != want {
+			t.Errorf("Allows(%q) = %v, want %v", route, got, want)
+		}
+	}
+	if !(APIKey{}).Allows("/v1/countries") {
+		t.Error("a key without routes is not allowed everywhere")
+	}
+}
+
+func TestKeyStoreReloadKeepsKeysOnError(t *testing.T) {
+	file := writeKeys(t)
+	store, err := NewKeyStore(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range map[string]string{
+		"malformed":     `{"keys": [`,
+		"invalid hash":  `{"keys": [{"label": "app", "hash": "abc", "enabled": true}]}`,
+		"missing label": `{"keys": [{"hash": "` + hashKey("other") + `", "enabled": true}]}`,
+		"duplicate label": `{"keys": [
+			{"label": "app", "hash": "` + hashKey("secret") + `", "enabled": true},
+			{"label": "app", "hash": "` + hashKey("other") + `", "enabled": true}
+		]}`,
+	} {
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Reload(); err == nil {
+			t.Errorf("%s: reloaded without an error", name)
+		}
+		if entry, ok := store.Lookup("secret"); !ok || entry.Label != "app" {
+			t.Errorf("%s: failed reload dropped the loaded keys", name)
+		}
+	}
+
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Reload(); err == nil {
+		t.Error("missing file: reloaded without an error")
+	}
+	if _, ok := store.Lookup("secret"); !ok {
+		t.Error("missing file: failed reload dropped the loaded keys")
+	}
+
+	// A successful reload replaces the keys
+	content := `{"keys": [{"label": "new", "hash": "` + hashKey("rotated") + `", "enabled": true}]}`
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Lookup("secret"); ok {
+		t.Error("reload kept a removed key")
+	}
+	if entry, ok := store.Lookup("rotated"); !ok || entry.Label != "new" {
+		t.Error("reload did not load the new key")
+	}
+}
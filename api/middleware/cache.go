@@ -0,0 +1,189 @@
+// cache.go contains the HTTP caching middleware: strong ETags, Last-Modified, conditional GETs
+// and per-route Cache-Control headers.
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+	"github.com/DoROAD-AI/gcr/compression"
+	"github.com/DoROAD-AI/gcr/version"
+)
+
+// CacheOptions holds the Cache-Control values sent with successful responses.
+type CacheOptions struct {
+	// Default is sent on routes without an entry in Routes; empty omits the header.
+	Default string
+	// Routes maps route templates (e.g. "/v1/alpha/:code") to their Cache-Control value.
+	Routes map[string]string
+}
+
+// cacheControl returns the Cache-Control value for a route template.
+func (o CacheOptions) cacheControl(route string) string {
+	if value, ok := o.Routes[route]; ok {
+		return value
+	}
+	return o.Default
+}
+
+// HTTPCache makes GET responses revalidatable. The ETag is derived from the build version, the
+// dataset and language mapping checksums, the group catalog version, the request path, the sorted
+// query parameters, Accept-Language, whether Accept asks for the envelope and the negotiated
+// content coding, so it only changes when the response can change and each compressed
+// representation gets its own strong tag. Last-Modified is the dataset load time. The validators and Cache-Control are only
+// attached to 2xx responses, so errors are never cached, and the preconditions are evaluated at
+// the same point: a 2xx response whose request carries a matching If-None-Match, or without one
+// an If-Modified-Since not older than the dataset, is turned into a 304 and its body dropped.
+// Errors such as 400 or 404 are sent as they are.
+func HTTPCache(opts CacheOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		etag := computeETag(c.Request)
+		lastModified := v1.DatasetLoadedAt.Truncate(time.Second)
+		cacheControl := opts.cacheControl(c.FullPath())
+
+		setValidators := func(header http.Header) {
+			header.Set("ETag", etag)
+			if !lastModified.IsZero() {
+				header.Set("Last-Modified", lastModified.Format(http.TimeFormat))
+			}
+			if cacheControl != "" {
+				header.Set("Cache-Control", cacheControl)
+			}
+			header.Add("Vary", "Accept-Language")
+			header.Add("Vary", "Accept")
+		}
+
+		c.Writer = &cacheWriter{
+			ResponseWriter: c.Writer,
+			setValidators:  setValidators,
+			notModified:    func() bool { return notModified(c.Request, etag, lastModified) },
+		}
+		c.Next()
+	}
+}
+
+// cacheWriter attaches the caching headers and answers preconditions once the handler picks a
+// 2xx status.
+type cacheWriter struct {
+	gin.ResponseWriter
+	setValidators func(http.Header)
+	notModified   func() bool
+	// decided is set once the status has been inspected; skipBody once it became a 304.
+	decided  bool
+	skipBody bool
+}
+
+// WriteHeader adds the validators for successful responses and replaces the status with 304 when
+// the request's preconditions match, before recording it.
+func (w *cacheWriter) WriteHeader(code int) {
+	if !w.decided && !w.Written() {
+		w.decided = true
+		if code >= 200 && code < 300 {
+			header := w.Header()
+			w.setValidators(header)
+			if w.notModified() {
+				for _, name := range []string{"Content-Type", "Content-Length", "Content-Encoding", "Content-Language"} {
+					header.Del(name)
+				}
+				code = http.StatusNotModified
+				w.skipBody = true
+			}
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write drops the body of 304 responses. Handlers that write without setting a status get the
+// implicit 200 inspected first.
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.WriteHeader(w.Status())
+	}
+	if w.skipBody {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString drops the body of 304 responses like Write.
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// computeETag hashes everything a GET response depends on into a strong entity tag.
+func computeETag(r *http.Request) string {
+	h := sha256.New()
+	for _, part := range []string{
+		version.Version,
+		v1.DatasetChecksum,
+		v1.LanguagesChecksum,
+		v1.Groups.Version,
+		r.URL.Path,
+		normalizeQuery(r.URL.Query()),
+		r.Header.Get("Accept-Language"),
+		strconv.FormatBool(v1.AcceptsEnvelope(r)),
+		compression.Negotiate(r.Header.Get("Accept-Encoding")),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// normalizeQuery encodes the query with sorted keys, dropping empty values, so equivalent
+// requests share an ETag regardless of parameter order.
+func normalizeQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		for _, value := range query[key] {
+			if value == "" {
+				continue
+			}
+			b.WriteString(url.QueryEscape(key))
+			b.WriteByte('=')
+			b.WriteString(url.QueryEscape(value))
+			b.WriteByte('&')
+		}
+	}
+	return b.String()
+}
+
+// notModified evaluates the conditional request headers per RFC 9110: If-None-Match takes
+// precedence and uses weak comparison; If-Modified-Since is only consulted without it. It is only
+// called for 2xx responses, so "*" always matches the current representation.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ims)
+		return err == nil && !lastModified.After(since)
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+// cacheRouter serves /ok with 200 and /missing and /invalid with problems behind HTTPCache.
+func cacheRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(HTTPCache(CacheOptions{Default: "public, max-age=60"}))
+	router.GET("/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"name": "Germany"})
+	})
+	router.GET("/missing", func(c *gin.Context) {
+		v1.AbortWithProblem(c, http.StatusNotFound, v1.CodeCountryNotFound, "code", "No country matches code 'zz'")
+	})
+	router.GET("/invalid", func(c *gin.Context) {
+		v1.AbortWithProblem(c, http.StatusBadRequest, v1.CodeInvalidParameter, "code", "Invalid code")
+	})
+	return router
+}
+
+func TestHTTPCacheConditionalRequests(t *testing.T) {
+	v1.DatasetLoadedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	router := cacheRouter()
+	since := v1.DatasetLoadedAt.Add(time.Hour).Format(http.TimeFormat)
+
+	for _, tc := range []struct {
+		path   string
+		status int
+		cached bool
+	}{
+		{"/ok", http.StatusNotModified, true},
+		{"/missing", http.StatusNotFound, false},
+		{"/invalid", http.StatusBadRequest, false},
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.Header.Set("If-Modified-Since", since)
+		router.ServeHTTP(w, req)
+
+		if w.Code != tc.status {
+			t.Errorf("%s with If-Modified-Since: status %d, want %d", tc.path, w.Code, tc.status)
+		}
+		if got := w.Header().Get("ETag") != ""; got != tc.cached {
+			t.Errorf("%s: ETag present %v, want %v", tc.path, got, tc.cached)
+		}
+		if tc.cached && w.Body.Len() != 0 {
+			t.Errorf("%s: 304 carries a body: %s", tc.path, w.Body)
+		}
+		if !tc.cached && w.Body.Len() == 0 {
+			t.Errorf("%s: error body dropped", tc.path)
+		}
+	}
+}
+
+func TestHTTPCacheIfNoneMatch(t *testing.T) {
+	v1.DatasetLoadedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	router := cacheRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" {
+		t.Fatalf("first request: status %d, ETag %q", w.Code, etag)
+	}
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set("If-None-Match", etag)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("matching If-None-Match: status %d, want 304", w.Code)
+	}
+
+	for _, inm := range []string{"*", `"other", *`} {
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodGet, "/ok", nil)
+		req.Header.Set("If-None-Match", inm)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s on an existing resource: status %d with %d bytes, want an empty 304", inm, w.Code, w.Body.Len())
+		}
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set("If-None-Match", `"other"`)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("mismatching If-None-Match: status %d, want 200", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/missing", nil)
+	req.Header.Set("If-None-Match", "*")
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("If-None-Match on a missing resource: status %d, want 404", w.Code)
+	}
+}
+
+func TestComputeETagCoversLoadedData(t *testing.T) {
+	defer func(dataset, languages string) {
+		v1.DatasetChecksum, v1.LanguagesChecksum = dataset, languages
+	}(v1.DatasetChecksum, v1.LanguagesChecksum)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/languages/de", nil)
+	v1.DatasetChecksum, v1.LanguagesChecksum = "countries-1", "languages-1"
+	etag := computeETag(req)
+
+	v1.LanguagesChecksum = "languages-2"
+	if computeETag(req) == etag {
+		t.Error("ETag unchanged after the language mapping changed")
+	}
+	v1.DatasetChecksum, v1.LanguagesChecksum = "countries-2", "languages-1"
+	if computeETag(req) == etag {
+		t.Error("ETag unchanged after the dataset changed")
+	}
+	v1.DatasetChecksum = "countries-1"
+	if computeETag(req) != etag {
+		t.Error("ETag changed for the same data")
+	}
+}
@@ -0,0 +1,122 @@
+// compress.go contains the response compression middleware.
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DoROAD-AI/gcr/compression"
+)
+
+// minCompressSize is the smallest first write worth compressing; below it the framing
+// overhead outweighs the savings.
+const minCompressSize = 1024
+
+// compressibleTypes are the Content-Type prefixes that are compressed.
+var compressibleTypes = []string{
+	"application/json",
+	"application/javascript",
+	"application/problem+json",
+	"application/vnd.gcr+json",
+	"application/xml",
+	"image/svg+xml",
+	"text/",
+}
+
+// Compress encodes response bodies with the coding negotiated from Accept-Encoding (zstd, br or
+// gzip). Responses that already carry a Content-Encoding, such as precomputed payloads, pass
+// through untouched, as do small bodies and non-textual content types.
+func Compress() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		coding := compression.Negotiate(c.GetHeader("Accept-Encoding"))
+		if coding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: c.Writer, coding: coding}
+		c.Writer = w
+		defer w.close()
+		c.Next()
+	}
+}
+
+// compressWriter decides on the first write whether to compress the body.
+type compressWriter struct {
+	gin.ResponseWriter
+	coding  string
+	decided bool
+	encoder compression.Writer
+}
+
+// start picks compression or pass-through based on the status, headers and first write size.
+func (w *compressWriter) start(size int) {
+	w.decided = true
+
+	header := w.Header()
+	status := w.Status()
+	if header.Get("Content-Encoding") != "" || size < minCompressSize ||
+		status == http.StatusNoContent || status == http.StatusNotModified || !compressible(header.Get("Content-Type")) {
+		return
+	}
+
+	encoder, err := compression.NewWriter(w.coding, w.ResponseWriter)
+	if err != nil {
+		return
+	}
+	header.Set("Content-Encoding", w.coding)
+	header.Del("Content-Length")
+	w.encoder = encoder
+}
+
+// Write compresses data once compression has been chosen.
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.start(len(data))
+	}
+	if w.encoder == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.encoder.Write(data)
+}
+
+// WriteString compresses s once compression has been chosen.
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush pushes buffered compressed data to the client.
+func (w *compressWriter) Flush() {
+	if w.encoder != nil {
+		if err := w.encoder.Flush(); err != nil {
+			slog.Error("Failed to flush compressed response", "error", err)
+		}
+	}
+	w.ResponseWriter.Flush()
+}
+
+// close finishes the compressed stream.
+func (w *compressWriter) close() {
+	if w.encoder == nil {
+		return
+	}
+	if err := w.encoder.Close(); err != nil {
+		slog.Error("Failed to finish compressed response", "error", err)
+	}
+	w.encoder = nil
+}
+
+// compressible reports whether a Content-Type is worth compressing.
+func compressible(contentType string) bool {
+	for _, prefix := range compressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+// largeBody is a JSON body above minCompressSize.
+var largeBody = `{"names": "` + strings.Repeat("Germany ", 256) + `"}`
+
+// compressRouter serves bodies of several sizes and content types behind Compress.
+func compressRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Compress())
+	router.GET("/large", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(largeBody))
+	})
+	router.GET("/small", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(`{"name": "Germany"}`))
+	})
+	router.GET("/png", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", []byte(largeBody))
+	})
+	router.GET("/encoded", func(c *gin.Context) {
+		c.Header("Content-Encoding", "gzip")
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(largeBody))
+	})
+	return router
+}
+
+// decode reverses coding on body.
+func decode(t *testing.T, coding string, body []byte) string {
+	t.Helper()
+	var r io.Reader
+	switch coding {
+	case "":
+		return string(body)
+	case "gzip":
+		gr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = gr
+	case "br":
+		r = brotli.NewReader(bytes.NewReader(body))
+	case "zstd":
+		zr, err := zstd.NewReader(bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		t.Fatalf("unexpected coding %q", coding)
+	}
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("decoding %s: %v", coding, err)
+	}
+	return string(decoded)
+}
+
+func TestCompressNegotiatesCoding(t *testing.T) {
+	router := compressRouter()
+
+	for accept, want := range map[string]string{
+		"":                               "",
+		"identity":                       "",
+		"gzip":                           "gzip",
+		"gzip, deflate, br":              "br",
+		"gzip, br, zstd":                 "zstd",
+		"zstd;q=0.5, br;q=0.8, gzip":     "gzip",
+		"br;q=0.9, gzip;q=0.1":           "br",
+		"zstd;q=0, br;q=0, gzip;q=0.001": "gzip",
+		"*":                              "zstd",
+		"*;q=0.5, gzip":                  "gzip",
+		"zstd;q=0, *":                    "br",
+		"gzip;q=0, br;q=0, zstd;q=0":     "",
+		"GZIP":                           "gzip",
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/large", nil)
+		if accept != "" {
+			req.Header.Set("Accept-Encoding", accept)
+		}
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Content-Encoding"); got != want {
+			t.Errorf("Accept-Encoding %q: Content-Encoding %q, want %q", accept, got, want)
+			continue
+		}
+		if got := w.Header().Values("Vary"); len(got) != 1 || got[0] != "Accept-Encoding" {
+			t.Errorf("Accept-Encoding %q: Vary %q, want Accept-Encoding", accept, got)
+		}
+		if got := decode(t, want, w.Body.Bytes()); got != largeBody {
+			t.Errorf("Accept-Encoding %q: body does not round-trip", accept)
+		}
+	}
+}
+
+func TestCompressSkipsSmallAndBinaryBodies(t *testing.T) {
+	router := compressRouter()
+
+	for path, want := range map[string]string{
+		"/large":   "zstd",
+		"/small":   "",
+		"/png":     "",
+		"/encoded": "gzip",
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", "zstd, br, gzip")
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Content-Encoding"); got != want {
+			t.Errorf("%s: Content-Encoding %q, want %q", path, got, want)
+		}
+		if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+			t.Errorf("%s: Vary %q, want Accept-Encoding", path, got)
+		}
+		if want != "zstd" && !strings.Contains(w.Body.String(), "Germany") {
+			t.Errorf("%s: body altered although it was not compressed", path)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// logging.go contains request-id propagation, structured access logging and panic recovery.
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+// RequestIDHeader carries the request id on requests and responses.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDKey is the gin context key under which the request id is stored.
+const RequestIDKey = "requestID"
+
+// maxRequestIDLength bounds client-supplied request ids.
+const maxRequestIDLength = 128
+
+// RequestID reuses a well-formed incoming X-Request-ID or generates a new one, and echoes it on
+// the response.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Set(RequestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// validRequestID accepts non-empty ids of printable ASCII up to maxRequestIDLength characters.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns 16 random bytes as hex.
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// AccessLog writes one structured record per request with the request id, route, status, latency,
+// client IP, API key label and query parameters. 5xx responses log at error level, 4xx at warn.
+func AccessLog(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("request_id", c.GetString(RequestIDKey)),
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+		}
+		if label := c.GetString(APIKeyLabelKey); label != "" {
+			attrs = append(attrs, slog.String("api_key", label))
+		}
+		if query := c.Request.URL.Query(); len(query) > 0 {
+			params := make([]any, 0, len(query))
+			for key, values := range query {
+				params = append(params, slog.Any(key, values))
+			}
+			attrs = append(attrs, slog.Group("query", params...))
+		}
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			attrs = append(attrs, slog.String("trace_id", span.TraceID().String()))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		logger.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// Recovery turns a handler panic into a 500 problem response and logs the panic with its stack trace.
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.ErrorContext(c.Request.Context(), "panic recovered",
+					slog.String("request_id", c.GetString(RequestIDKey)),
+					slog.String("route", c.FullPath()),
+					slog.String("panic", fmt.Sprint(rec)),
+					slog.String("stack", string(debug.Stack())),
+				)
+				v1.AbortWithProblem(c, http.StatusInternalServerError, v1.CodeInternalError, "", "Internal server error")
+			}
+		}()
+		c.Next()
+	}
+}
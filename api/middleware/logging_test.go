@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+// loggingRouter serves /ok and /panic behind the logging middleware in the order main uses,
+// writing JSON records to buf.
+func loggingRouter(buf *bytes.Buffer) *gin.Engine {
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), AccessLog(logger), Recovery(logger))
+	router.GET("/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"request_id": c.GetString(RequestIDKey)})
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	return router
+}
+
+// logRecords decodes the JSON records written to buf.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	router := loggingRouter(&buf)
+
+	for _, tc := range []struct {
+		name, incoming string
+		reused         bool
+	}{
+		{"valid id", "req-42.abc", true},
+		{"missing id", "", false},
+		{"control characters", "bad\tid", false},
+		{"spaces", "two words", false},
+		{"too long", strings.Repeat("x", maxRequestIDLength+1), false},
+	} {
+		buf.Reset()
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+		if tc.incoming != "" {
+			req.Header.Set(RequestIDHeader, tc.incoming)
+		}
+		router.ServeHTTP(w, req)
+
+		id := w.Header().Get(RequestIDHeader)
+		if tc.reused && id != tc.incoming {
+			t.Errorf("%s: response id %q, want %q", tc.name, id, tc.incoming)
+		}
+		if !tc.reused && (id == tc.incoming || len(id) != 32) {
+			t.Errorf("%s: response id %q, want a generated 32 character id", tc.name, id)
+		}
+		var body map[string]string
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+		if body["request_id"] != id {
+			t.Errorf("%s: handler saw id %q, response carries %q", tc.name, body["request_id"], id)
+		}
+		if records := logRecords(t, &buf); len(records) != 1 || records[0]["request_id"] != id {
+			t.Errorf("%s: access log %v, want one record with request_id %q", tc.name, records, id)
+		}
+	}
+
+	// Generated ids are unique per request
+	ids := make(map[string]bool)
+	for i := 0; i < 10; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+		ids[w.Header().Get(RequestIDHeader)] = true
+	}
+	if len(ids) != 10 {
+		t.Errorf("10 requests got %d distinct ids", len(ids))
+	}
+}
+
+func TestRecoveryWritesProblemAndLogsStack(t *testing.T) {
+	var buf bytes.Buffer
+	router := loggingRouter(&buf)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(RequestIDHeader, "panic-1")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status %d, want 500", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, v1.ProblemContentType) {
+		t.Errorf("Content-Type %q, want %s", got, v1.ProblemContentType)
+	}
+	var problem v1.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Status != http.StatusInternalServerError || problem.Code != v1.CodeInternalError {
+		t.Errorf("problem %+v, want internal_error", problem)
+	}
+	if strings.Contains(w.Body.String(), "boom") {
+		t.Error("response leaks the panic value")
+	}
+
+	records := logRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("%d log records, want the panic and the access log", len(records))
+	}
+	panicked, access := records[0], records[1]
+	if panicked["msg"] != "panic recovered" || panicked["level"] != "ERROR" || panicked["panic"] != "boom" ||
+		panicked["request_id"] != "panic-1" || panicked["route"] != "/panic" {
+		t.Errorf("panic record %v", panicked)
+	}
+	if stack, _ := panicked["stack"].(string); !strings.Contains(stack, "logging_test.go") {
+		t.Errorf("panic record stack %q lacks the panicking handler", stack)
+	}
+	if access["msg"] != "request" || access["level"] != "ERROR" || access["status"] != float64(http.StatusInternalServerError) {
+		t.Errorf("access record %v, want an error-level request with status 500", access)
+	}
+}
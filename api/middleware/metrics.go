@@ -0,0 +1,99 @@
+// metrics.go contains the Prometheus instrumentation middleware and dataset gauges.
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+// Metrics holds the collectors exposed on /metrics.
+type Metrics struct {
+	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	size     *prometheus.HistogramVec
+}
+
+// requestLabels are the labels shared by all HTTP metrics.
+var requestLabels = []string{"route", "method", "status", "api_key"}
+
+// NewMetrics creates a registry with HTTP, dataset, Go runtime and process collectors.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gcr_http_requests_total",
+			Help: "Number of HTTP requests by route template, method, status code and API key label.",
+		}, requestLabels),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gcr_http_request_duration_seconds",
+			Help:    "HTTP request latency by route template, method, status code and API key label.",
+			Buckets: prometheus.DefBuckets,
+		}, requestLabels),
+		size: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gcr_http_response_size_bytes",
+			Help:    "HTTP response body size by route template, method, status code and API key label.",
+			Buckets: prometheus.ExponentialBuckets(256, 4, 8), // 256B .. 4MiB
+		}, requestLabels),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.latency,
+		m.size,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "gcr_dataset_records",
+			Help: "Number of countries in the loaded dataset.",
+		}, func() float64 {
+			return float64(len(v1.Countries))
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "gcr_dataset_last_reload_timestamp_seconds",
+			Help: "Unix time the dataset was last loaded.",
+		}, func() float64 {
+			if v1.DatasetLoadedAt.IsZero() {
+				return 0
+			}
+			return float64(v1.DatasetLoadedAt.UnixNano()) / 1e9
+		}),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Middleware records every request. Requests that match no route are labeled "unmatched" to keep
+// label cardinality bounded; anonymous requests have an empty api_key label.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		labels := prometheus.Labels{
+			"route":   route,
+			"method":  c.Request.Method,
+			"status":  strconv.Itoa(c.Writer.Status()),
+			"api_key": c.GetString(APIKeyLabelKey),
+		}
+
+		m.requests.With(labels).Inc()
+		m.latency.With(labels).Observe(time.Since(start).Seconds())
+		m.size.With(labels).Observe(float64(max(c.Writer.Size(), 0)))
+	}
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMetricsLabelRequestsByRouteAndKey(t *testing.T) {
+	store, err := NewKeyStore(writeKeys(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics := NewMetrics()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(metrics.Middleware())
+	router.GET("/metrics", metrics.Handler())
+	v1Group := router.Group("/v1", APIKeyAuth(store))
+	v1Group.GET("/alpha/:code", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"cca3": c.Param("code")})
+	})
+
+	for _, tc := range []struct{ path, key string }{
+		{"/v1/alpha/DE", "secret"},
+		{"/v1/alpha/FR", "secret"},
+		{"/v1/alpha/IT", ""},
+		{"/v1/nowhere", ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		if tc.key != "" {
+			req.Header.Set(APIKeyHeader, tc.key)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("/metrics: status %d", w.Code)
+	}
+	exposition := w.Body.String()
+
+	for _, want := range []string{
+		`gcr_http_requests_total{api_key="app",method="GET",route="/v1/alpha/:code",status="200"} 2`,
+		`gcr_http_requests_total{api_key="",method="GET",route="/v1/alpha/:code",status="401"} 1`,
+		`gcr_http_requests_total{api_key="",method="GET",route="unmatched",status="404"} 1`,
+		`gcr_http_request_duration_seconds_count{api_key="app",method="GET",route="/v1/alpha/:code",status="200"} 2`,
+		`gcr_http_request_duration_seconds_bucket{api_key="app",method="GET",route="/v1/alpha/:code",status="200",le="+Inf"} 2`,
+		`gcr_http_response_size_bytes_count{api_key="app",method="GET",route="/v1/alpha/:code",status="200"} 2`,
+		"gcr_dataset_records ",
+	} {
+		if !strings.Contains(exposition, want) {
+			t.Errorf("/metrics lacks %s", want)
+		}
+	}
+	for _, raw := range []string{"/v1/alpha/DE", "/v1/nowhere"} {
+		if strings.Contains(exposition, `"`+raw+`"`) {
+			t.Errorf("/metrics labels a series with the raw path %s", raw)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// ratelimit.go contains token-bucket rate limiting keyed by API key or client IP.
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+// Duration is a time.Duration that unmarshals from a Go duration string such as "1m".
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"1m\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Tier defines a token bucket: Limit requests per Period, with bursts of up to Burst requests.
+type Tier struct {
+	Limit  int      `json:"limit"`
+	Period Duration `json:"period"`
+	Burst  int      `json:"burst,omitempty"`
+}
+
+// capacity returns the bucket size, defaulting to Limit when Burst is unset.
+func (t Tier) capacity() float64 {
+	if t.Burst > 0 {
+		return float64(t.Burst)
+	}
+	return float64(t.Limit)
+}
+
+// rate returns the refill rate in tokens per second.
+func (t Tier) rate() float64 {
+	return float64(t.Limit) / time.Duration(t.Period).Seconds()
+}
+
+// RateLimitConfig maps clients to tiers. Anonymous clients are keyed by IP and use AnonymousTier;
+// API keys use their own tier from the keys file, or KeyTier when none is set.
+type RateLimitConfig struct {
+	AnonymousTier string          `json:"anonymousTier"`
+	KeyTier       string          `json:"keyTier"`
+	Tiers         map[string]Tier `json:"tiers"`
+}
+
+// LoadRateLimitConfig reads and validates a rate limit configuration file.
+func LoadRateLimitConfig(filename string) (RateLimitConfig, error) {
+	var cfg RateLimitConfig
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read rate limit file: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse rate limit file: %w", err)
+	}
+	return cfg, cfg.Validate()
+}
+
+// Validate checks that every tier is usable and that the default tiers exist.
+func (cfg RateLimitConfig) Validate() error {
+	for name, tier := range cfg.Tiers {
+		if tier.Limit <= 0 || tier.Period <= 0 || tier.Burst < 0 {
+			return fmt.Errorf("invalid rate limit tier %q: limit and period must be positive", name)
+		}
+	}
+	for _, name := range []string{cfg.AnonymousTier, cfg.KeyTier} {
+		if _, ok := cfg.Tiers[name]; !ok {
+			return fmt.Errorf("unknown rate limit tier %q", name)
+		}
+	}
+	return nil
+}
+
+// Bucket returns the bucket key and tier of a client. Authenticated clients, with a non-empty
+// label, are keyed by label and use keyTier or KeyTier; anonymous ones are keyed by clientIP and
+// use AnonymousTier.
+func (cfg RateLimitConfig) Bucket(clientIP, label, keyTier string) (string, Tier) {
+	if label == "" {
+		return "ip:" + clientIP, cfg.Tiers[cfg.AnonymousTier]
+	}
+	if tier, ok := cfg.Tiers[keyTier]; ok {
+		return "key:" + label, tier
+	}
+	// Keys without a tier, or pointing at one that no longer exists, use the default key tier
+	return "key:" + label, cfg.Tiers[cfg.KeyTier]
+}
+
+// Quota is the outcome of taking a token from a bucket.
+type Quota struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token is available; zero when Allowed.
+	RetryAfter time.Duration
+}
+
+// RateLimitStore keeps bucket state. MemoryStore serves a single process; a shared backend can
+// implement the same interface to limit across replicas.
+type RateLimitStore interface {
+	Take(key string, tier Tier, now time.Time) Quota
+}
+
+// bucket is the state of one token bucket.
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// full is when the bucket will have refilled to capacity; until then evicting it would
+	// hand the client a fresh bucket early.
+	full time.Time
+}
+
+// MemoryStore is an in-process RateLimitStore.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// sweepInterval is how often full buckets are evicted from a MemoryStore.
+const sweepInterval = 10 * time.Minute
+
+// NewMemoryStore returns an empty in-process store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Take refills the bucket for key and consumes one token if available.
+func (s *MemoryStore) Take(key string, tier Tier, now time.Time) Quota {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	capacity, rate := tier.capacity(), tier.rate()
+
+	if now.Sub(s.lastSweep) > sweepInterval {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, last: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	quota := Quota{Limit: int(capacity)}
+	if b.tokens >= 1 {
+		b.tokens--
+		quota.Allowed = true
+	} else {
+		quota.RetryAfter = secondsToDuration((1 - b.tokens) / rate)
+	}
+	quota.Remaining = int(b.tokens)
+	quota.Reset = secondsToDuration((capacity - b.tokens) / rate)
+	b.full = now.Add(quota.Reset)
+	return quota
+}
+
+// sweep evicts buckets that have refilled completely; a new bucket for the key starts full, so
+// evicting them changes nothing. Buckets of long-period tiers stay until they have refilled.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+// secondsToDuration converts fractional seconds to a time.Duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// ceilSeconds formats a duration as whole seconds, rounded up, for rate limit headers.
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// RateLimit enforces the configured tiers. It runs before APIKeyAuth, so requests that
+// authentication rejects are limited too: requests with a known, enabled key in keys are keyed by
+// its label and all others, including missing and unknown keys, by client IP. keys is nil when
+// authentication is disabled. Every response carries RateLimit-Limit/Remaining/Reset headers;
+// exhausted clients get 429 with Retry-After.
+func RateLimit(cfg RateLimitConfig, store RateLimitStore, keys *KeyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		label, keyTier := keys.Identify(c.GetHeader(APIKeyHeader))
+		key, tier := cfg.Bucket(c.ClientIP(), label, keyTier)
+		quota := store.Take(key, tier, time.Now())
+		c.Header("RateLimit-Limit", strconv.Itoa(quota.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(quota.Remaining))
+		c.Header("RateLimit-Reset", ceilSeconds(quota.Reset))
+
+		if !quota.Allowed {
+			retry := ceilSeconds(quota.RetryAfter)
+			c.Header("Retry-After", retry)
+			v1.AbortWithProblem(c, http.StatusTooManyRequests, v1.CodeRateLimited, "", "Rate limit exceeded, retry in "+retry+" seconds")
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+func TestMemoryStoreKeepsDrainedLongPeriodBuckets(t *testing.T) {
+	store := NewMemoryStore()
+	daily := Tier{Limit: 3, Period: Duration(24 * time.Hour)}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if quota := store.Take("key:a", daily, now); !quota.Allowed {
+			t.Fatalf("request %d denied within the limit", i+1)
+		}
+	}
+	if quota := store.Take("key:a", daily, now); quota.Allowed {
+		t.Fatal("request past the limit allowed")
+	}
+
+	// Past the sweep interval but far from a refill: the sweep must not reset the bucket
+	now = now.Add(sweepInterval + time.Minute)
+	if quota := store.Take("key:a", daily, now); quota.Allowed {
+		t.Fatal("drained daily bucket was reset by the sweep")
+	}
+
+	// A full day later the bucket has refilled and the sweep may evict it
+	now = now.Add(24 * time.Hour)
+	store.Take("key:b", daily, now)
+	if _, ok := store.buckets["key:a"]; ok {
+		t.Error("refilled bucket was not evicted")
+	}
+}
+
+// rateLimitRouter serves /v1/all behind RateLimit and APIKeyAuth, in the order main uses, with
+// two requests per hour for anonymous clients and three for keys.
+func rateLimitRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	keys, err := NewKeyStore(writeKeys(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	limits := RateLimitConfig{
+		AnonymousTier: "anonymous",
+		KeyTier:       "standard",
+		Tiers: map[string]Tier{
+			"anonymous": {Limit: 2, Period: Duration(time.Hour)},
+			"standard":  {Limit: 3, Period: Duration(time.Hour)},
+		},
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RateLimit(limits, NewMemoryStore(), keys), APIKeyAuth(keys))
+	router.GET("/v1/all", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+	return router
+}
+
+// rateLimited sends a request with key from the client at 192.0.2.1.
+func rateLimited(router *gin.Engine, key string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/all", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	if key != "" {
+		req.Header.Set(APIKeyHeader, key)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	router := rateLimitRouter(t)
+
+	for i, remaining := range []string{"2", "1", "0"} {
+		w := rateLimited(router, "secret")
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status %d, want 200", i+1, w.Code)
+		}
+		if got := w.Header().Get("RateLimit-Limit"); got != "3" {
+			t.Errorf("request %d: RateLimit-Limit %q, want 3", i+1, got)
+		}
+		if got := w.Header().Get("RateLimit-Remaining"); got != remaining {
+			t.Errorf("request %d: RateLimit-Remaining %q, want %s", i+1, got, remaining)
+		}
+		if reset, err := strconv.Atoi(w.Header().Get("RateLimit-Reset")); err != nil || reset <= 0 || reset > 3600 {
+			t.Errorf("request %d: RateLimit-Reset %q, want 1-3600 seconds", i+1, w.Header().Get("RateLimit-Reset"))
+		}
+		if w.Header().Get("Retry-After") != "" {
+			t.Errorf("request %d: Retry-After on an allowed request", i+1)
+		}
+	}
+
+	w := rateLimited(router, "secret")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("request past the limit: status %d, want 429", w.Code)
+	}
+	// One of three tokens per hour refills every 20 minutes
+	if got := w.Header().Get("Retry-After"); got != "1200" {
+		t.Errorf("Retry-After %q, want 1200", got)
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, v1.ProblemContentType) {
+		t.Errorf("Content-Type %q, want %s", got, v1.ProblemContentType)
+	}
+	var problem v1.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Status != http.StatusTooManyRequests || problem.Code != v1.CodeRateLimited || !strings.Contains(problem.Detail, "1200 seconds") {
+		t.Errorf("problem %+v, want rate_limited with the retry delay", problem)
+	}
+
+	// Other clients keep their own buckets
+	if w := rateLimited(router, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous client: status %d, want 401", w.Code)
+	}
+}
+
+func TestRateLimitChargesRejectedRequestsToClientIP(t *testing.T) {
+	router := rateLimitRouter(t)
+
+	for i, key := range []string{"", "guess", "revoked"} {
+		w := rateLimited(router, key)
+		want := http.StatusUnauthorized
+		if i == 2 {
+			want = http.StatusTooManyRequests
+		}
+		if w.Code != want {
+			t.Errorf("request %d with key %q: status %d, want %d", i+1, key, w.Code, want)
+		}
+	}
+
+	// A valid key is limited separately from its client IP
+	if w := rateLimited(router, "secret"); w.Code != http.StatusOK {
+		t.Errorf("valid key after throttled guesses: status %d, want 200", w.Code)
+	}
+}
@@ -0,0 +1,68 @@
+// tracing.go contains the OpenTelemetry middleware creating one server span per request.
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies spans created by this package.
+const tracerName = "github.com/DoROAD-AI/gcr/api/middleware"
+
+// Tracing continues the trace from an incoming traceparent header (or starts a new one) and wraps
+// the request in a server span named after the route template. Path and query parameters are
+// recorded as gcr.param.* and gcr.query.* attributes. Handlers reach the span through
+// c.Request.Context().
+func Tracing() gin.HandlerFunc {
+	tracer := otel.Tracer(tracerName)
+
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		name := c.Request.Method + " " + route
+		if route == "" {
+			name = c.Request.Method
+		}
+
+		attrs := []attribute.KeyValue{
+			attribute.String("http.request.method", c.Request.Method),
+			attribute.String("url.path", c.Request.URL.Path),
+			attribute.String("client.address", c.ClientIP()),
+		}
+		if route != "" {
+			attrs = append(attrs, attribute.String("http.route", route))
+		}
+		for _, param := range c.Params {
+			attrs = append(attrs, attribute.String("gcr.param."+param.Key, param.Value))
+		}
+		for key, values := range c.Request.URL.Query() {
+			attrs = append(attrs, attribute.String("gcr.query."+key, strings.Join(values, ",")))
+		}
+
+		ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if label := c.GetString(APIKeyLabelKey); label != "" {
+			span.SetAttributes(attribute.String("gcr.api_key", label))
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last())
+		}
+	}
+}
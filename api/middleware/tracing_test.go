@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+	"github.com/DoROAD-AI/gcr/telemetry"
+)
+
+var (
+	recorderOnce sync.Once
+	recorder     *tracetest.SpanRecorder
+)
+
+// spanRecorder installs, once per test binary, a global tracer provider recording every span and
+// the W3C propagator from telemetry.SetupTracing. Tracers obtained before the first call, such as
+// the one in api/v1, delegate to it.
+func spanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorderOnce.Do(func() {
+		recorder = tracetest.NewSpanRecorder()
+		if _, err := telemetry.SetupTracing(context.Background(), telemetry.TracingOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	})
+	return recorder
+}
+
+// spansOf returns the ended spans of traceID, keyed by name.
+func spansOf(recorder *tracetest.SpanRecorder, traceID trace.TraceID) map[string]sdktrace.ReadOnlySpan {
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID() == traceID {
+			spans[span.Name()] = span
+		}
+	}
+	return spans
+}
+
+// attributeOf returns the value of key on span.
+func attributeOf(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+// tracingRouter serves GetCountries and a failing route behind Tracing.
+func tracingRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Tracing())
+	router.GET("/v1/countries", v1.GetCountries)
+	router.GET("/v1/fail", func(c *gin.Context) { c.Status(http.StatusServiceUnavailable) })
+	return router
+}
+
+func TestTracingContinuesIncomingTrace(t *testing.T) {
+	recorder := spanRecorder(t)
+	router := tracingRouter()
+
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parent  = "00f067aa0ba902b7"
+	)
+	req := httptest.NewRequest(http.MethodGet, "/v1/countries?independent=true&fields=cca3", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parent+"-01")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+
+	id, _ := trace.TraceIDFromHex(traceID)
+	spans := spansOf(recorder, id)
+	server, ok := spans["GET /v1/countries"]
+	if !ok {
+		t.Fatalf("no server span in trace %s, got %v", traceID, spans)
+	}
+	if server.SpanKind() != trace.SpanKindServer {
+		t.Errorf("server span kind %v", server.SpanKind())
+	}
+	if got := server.Parent().SpanID().String(); got != parent || !server.Parent().IsRemote() {
+		t.Errorf("server span parent %s (remote %v), want remote %s", got, server.Parent().IsRemote(), parent)
+	}
+	for key, want := range map[attribute.Key]attribute.Value{
+		"http.route":                attribute.StringValue("/v1/countries"),
+		"http.request.method":       attribute.StringValue(http.MethodGet),
+		"http.response.status_code": attribute.IntValue(http.StatusOK),
+		"gcr.query.independent":     attribute.StringValue("true"),
+	} {
+		if got, ok := attributeOf(server, key); !ok || got != want {
+			t.Errorf("server span %s = %v, want %v", key, got.Emit(), want.Emit())
+		}
+	}
+
+	for _, name := range []string{"filterCountries", "selectFields"} {
+		child, ok := spans[name]
+		if !ok {
+			t.Errorf("no %s span in trace %s", name, traceID)
+			continue
+		}
+		if child.Parent().SpanID() != server.SpanContext().SpanID() {
+			t.Errorf("%s span parent %s, want the server span %s", name, child.Parent().SpanID(), server.SpanContext().SpanID())
+		}
+	}
+	if got, ok := attributeOf(spans["filterCountries"], "gcr.filter.independent"); !ok || got.AsString() != "true" {
+		t.Errorf("filterCountries gcr.filter.independent = %q, want true", got.Emit())
+	}
+	if got, ok := attributeOf(spans["selectFields"], "gcr.fields"); !ok || got.AsString() != "cca3" {
+		t.Errorf("selectFields gcr.fields = %q, want cca3", got.Emit())
+	}
+}
+
+func TestTracingStartsTraceAndMarksServerErrors(t *testing.T) {
+	recorder := spanRecorder(t)
+	router := tracingRouter()
+
+	before := len(recorder.Ended())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/fail", nil))
+
+	ended := recorder.Ended()[before:]
+	if len(ended) != 1 {
+		t.Fatalf("%d spans ended, want 1", len(ended))
+	}
+	span := ended[0]
+	if span.Parent().IsValid() {
+		t.Errorf("span without traceparent has parent %s", span.Parent().SpanID())
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("503 span status %v, want Error", span.Status().Code)
+	}
+	if got, _ := attributeOf(span, "http.response.status_code"); got.AsInt64() != http.StatusServiceUnavailable {
+		t.Errorf("status attribute %v, want 503", got.Emit())
+	}
+}
@@ -0,0 +1,52 @@
+// validation.go contains the middleware enabling strict request validation per route.
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+// StrictOptions selects the routes validated strictly.
+type StrictOptions struct {
+	// Default applies to routes without an entry in Routes.
+	Default bool
+	// Routes maps route templates (e.g. "/v1/alpha") to their strict setting.
+	Routes map[string]bool
+	// RoutePrefix is the group prefix stripped before looking up the v1 rules.
+	RoutePrefix string
+}
+
+// StrictValidation rejects requests with unknown query parameters, malformed codes, oversize code
+// lists or unknown field paths on routes where strict mode is on, answering 400 with every
+// problem listed. Clients can opt into strict mode per request with strict=true, but cannot
+// opt out of a route configured as strict.
+func StrictValidation(opts StrictOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		strict, ok := opts.Routes[c.FullPath()]
+		if !ok {
+			strict = opts.Default
+		}
+
+		switch value := c.Query("strict"); strings.ToLower(value) {
+		case "", "false":
+		case "true":
+			strict = true
+		default:
+			v1.AbortWithProblem(c, http.StatusBadRequest, v1.CodeInvalidParameter, "strict",
+				"invalid boolean value: "+value+" (must be 'true' or 'false')")
+			return
+		}
+
+		if strict {
+			if problems := v1.ValidateRequest(c, opts.RoutePrefix); len(problems) > 0 {
+				v1.AbortWithInvalidParams(c, problems)
+				return
+			}
+		}
+		c.Next()
+	}
+}
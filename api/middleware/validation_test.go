@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+)
+
+// strictRouter serves a few /v1 routes behind StrictValidation, with /v1/alpha/:code configured
+// as strict and every other route following def.
+func strictRouter(def bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	group := router.Group("/v1")
+	group.Use(StrictValidation(StrictOptions{
+		Default:     def,
+		Routes:      map[string]bool{"/v1/alpha/:code": true},
+		RoutePrefix: "/v1",
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	group.GET("/all", ok)
+	group.GET("/alpha/:code", ok)
+	group.GET("/ccn3/:code", ok)
+	return router
+}
+
+// strictProblem serves target and decodes the problem, or returns the status alone for 2xx.
+func strictProblem(t *testing.T, router *gin.Engine, target string) (int, v1.Problem) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	var problem v1.Problem
+	if w.Code >= http.StatusBadRequest {
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+			t.Fatalf("%s: %v", target, err)
+		}
+	}
+	return w.Code, problem
+}
+
+func TestStrictValidationPerRoute(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		def    bool
+		target string
+		status int
+	}{
+		{"lenient route", false, "/v1/all?foo=1", http.StatusOK},
+		{"strict requested", false, "/v1/all?foo=1&strict=true", http.StatusBadRequest},
+		{"strict requested in upper case", false, "/v1/all?foo=1&strict=TRUE", http.StatusBadRequest},
+		{"strict route", false, "/v1/alpha/DE?foo=1", http.StatusBadRequest},
+		{"strict route cannot opt out", false, "/v1/alpha/DE?foo=1&strict=false", http.StatusBadRequest},
+		{"strict route, valid request", false, "/v1/alpha/DE?fields=cca3", http.StatusOK},
+		{"strict default", true, "/v1/all?foo=1", http.StatusBadRequest},
+		{"strict default cannot opt out", true, "/v1/all?foo=1&strict=false", http.StatusBadRequest},
+	} {
+		status, problem := strictProblem(t, strictRouter(tc.def), tc.target)
+		if status != tc.status {
+			t.Errorf("%s: %s: status %d, want %d", tc.name, tc.target, status, tc.status)
+			continue
+		}
+		if status == http.StatusBadRequest && (problem.Code != v1.CodeValidationFailed || problem.Param != "foo") {
+			t.Errorf("%s: problem %s on %q, want validation_failed on foo", tc.name, problem.Code, problem.Param)
+		}
+	}
+}
+
+func TestStrictValidationRejectsInvalidStrictValue(t *testing.T) {
+	status, problem := strictProblem(t, strictRouter(false), "/v1/all?strict=yes")
+	if status != http.StatusBadRequest || problem.Code != v1.CodeInvalidParameter || problem.Param != "strict" {
+		t.Errorf("strict=yes: status %d, problem %s on %q; want 400 invalid_parameter on strict", status, problem.Code, problem.Param)
+	}
+}
+
+func TestStrictValidationSuggestsNames(t *testing.T) {
+	router := strictRouter(true)
+
+	for _, tc := range []struct {
+		target, param, suggestion string
+	}{
+		{"/v1/all?feilds=cca3", "feilds", "fields"},
+		{"/v1/all?independant=true", "independant", "independent"},
+		{"/v1/all?fields=populaton", "fields", "population"},
+		{"/v1/all?fields=name.comon", "fields", "name.common"},
+	} {
+		status, problem := strictProblem(t, router, tc.target)
+		if status != http.StatusBadRequest || problem.Code != v1.CodeValidationFailed {
+			t.Errorf("%s: status %d, problem %s; want 400 validation_failed", tc.target, status, problem.Code)
+			continue
+		}
+		if len(problem.InvalidParams) != 1 {
+			t.Errorf("%s: invalid params %+v, want one", tc.target, problem.InvalidParams)
+			continue
+		}
+		got := problem.InvalidParams[0]
+		if got.Param != tc.param || got.Suggestion != tc.suggestion {
+			t.Errorf("%s: %s suggesting %q, want %s suggesting %q", tc.target, got.Param, got.Suggestion, tc.param, tc.suggestion)
+		}
+		if want := "(did you mean '" + tc.suggestion + "'?)"; !strings.Contains(problem.Detail, want) {
+			t.Errorf("%s: detail %q lacks %q", tc.target, problem.Detail, want)
+		}
+	}
+}
+
+func TestStrictValidationRejectsMalformedPathCodes(t *testing.T) {
+	router := strictRouter(true)
+
+	for _, tc := range []struct {
+		target string
+		status int
+	}{
+		{"/v1/ccn3/abc", http.StatusBadRequest},
+		{"/v1/ccn3/27", http.StatusBadRequest},
+		{"/v1/ccn3/276", http.StatusOK},
+		{"/v1/alpha/DEUT", http.StatusBadRequest},
+		{"/v1/alpha/D3", http.StatusBadRequest},
+		{"/v1/alpha/DEU", http.StatusOK},
+		{"/v1/alpha/276", http.StatusOK},
+	} {
+		status, problem := strictProblem(t, router, tc.target)
+		if status != tc.status {
+			t.Errorf("%s: status %d, want %d", tc.target, status, tc.status)
+			continue
+		}
+		if status == http.StatusBadRequest && (problem.Code != v1.CodeValidationFailed || problem.Param != "code") {
+			t.Errorf("%s: problem %s on %q, want validation_failed on code", tc.target, problem.Code, problem.Param)
+		}
+	}
+}
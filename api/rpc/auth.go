@@ -0,0 +1,109 @@
+// auth.go contains the gRPC counterparts of the API key and rate limit middleware, reading the
+// key from the dapi-key metadata.
+package rpc
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/DoROAD-AI/gcr/api/middleware"
+)
+
+// healthPrefix is the method prefix of the health service, which probes reach without a key like
+// the HTTP /healthz route.
+const healthPrefix = "/grpc.health.v1.Health/"
+
+// Guard holds the API keys and rate limits enforced on gRPC calls; nil fields disable the check.
+// Store should be the one the HTTP rate limit middleware uses, so both transports share quotas.
+type Guard struct {
+	Keys   *middleware.KeyStore
+	Limits *middleware.RateLimitConfig
+	Store  middleware.RateLimitStore
+}
+
+// check rate limits and authenticates a call to method. Calls are charged before the key is
+// checked, calls without a known, enabled key to the peer IP, so rejected calls count too.
+// Exhausted clients get ResourceExhausted with a retry-after header in seconds, missing and
+// unknown keys Unauthenticated, and disabled keys and keys not allowed on the method
+// PermissionDenied.
+func (g Guard) check(ctx context.Context, method string) error {
+	if strings.HasPrefix(method, healthPrefix) {
+		return nil
+	}
+
+	var key string
+	if values := metadata.ValueFromIncomingContext(ctx, middleware.APIKeyHeader); len(values) > 0 {
+		key = values[0]
+	}
+
+	if g.Limits != nil && g.Store != nil {
+		label, tier := g.Keys.Identify(key)
+		bucket, t := g.Limits.Bucket(peerIP(ctx), label, tier)
+		quota := g.Store.Take(bucket, t, time.Now())
+		if !quota.Allowed {
+			retry := strconv.Itoa(int(math.Ceil(quota.RetryAfter.Seconds())))
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retry))
+			return status.Error(codes.ResourceExhausted, "rate limit exceeded, retry in "+retry+" seconds")
+		}
+	}
+
+	if g.Keys != nil {
+		if key == "" {
+			return status.Error(codes.Unauthenticated, "missing API key in "+middleware.APIKeyHeader+" metadata")
+		}
+		entry, ok := g.Keys.Lookup(key)
+		if !ok {
+			return status.Error(codes.Unauthenticated, "invalid API key")
+		}
+		if !entry.Enabled {
+			return status.Error(codes.PermissionDenied, "API key is disabled")
+		}
+		if !entry.Allows(method) {
+			return status.Error(codes.PermissionDenied, "API key is not allowed to call "+method)
+		}
+	}
+	return nil
+}
+
+// peerIP returns the IP address of the calling client, or "" when unknown.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// unaryGuard rejects unary calls that fail the guard before the handler runs.
+func unaryGuard(g Guard) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := g.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamGuard rejects streaming calls that fail the guard before the handler runs.
+func streamGuard(g Guard) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := g.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/DoROAD-AI/gcr/api/middleware"
+)
+
+// testGuard returns a guard with the enabled key "secret", limited to two calls per hour, and
+// the disabled key "revoked"; calls without a valid key are limited to three per hour.
+func testGuard(t *testing.T) Guard {
+	t.Helper()
+	hash := func(key string) string {
+		sum := sha256.Sum256([]byte(key))
+		return hex.EncodeToString(sum[:])
+	}
+	file := filepath.Join(t.TempDir(), "keys.json")
+	keys := `{"keys": [
+		{"label": "app", "hash": "` + hash("secret") + `", "enabled": true, "routes": ["/gcr.v1.CountryService/*"]},
+		{"label": "old", "hash": "` + hash("revoked") + `", "enabled": false}
+	]}`
+	if err := os.WriteFile(file, []byte(keys), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store, err := middleware.NewKeyStore(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limits := middleware.RateLimitConfig{
+		AnonymousTier: "anonymous",
+		KeyTier:       "hourly",
+		Tiers: map[string]middleware.Tier{
+			"anonymous": {Limit: 3, Period: middleware.Duration(time.Hour)},
+			"hourly":    {Limit: 2, Period: middleware.Duration(time.Hour)},
+		},
+	}
+	return Guard{Keys: store, Limits: &limits, Store: middleware.NewMemoryStore()}
+}
+
+// withKey returns an incoming call context carrying key in the dapi-key metadata.
+func withKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(middleware.APIKeyHeader, key))
+}
+
+func TestGuard(t *testing.T) {
+	guard := testGuard(t)
+	const get = "/gcr.v1.CountryService/Get"
+
+	for _, tc := range []struct {
+		name   string
+		ctx    context.Context
+		method string
+		code   codes.Code
+	}{
+		{"missing key", context.Background(), get, codes.Unauthenticated},
+		{"unknown key", withKey("guess"), get, codes.Unauthenticated},
+		{"disabled key", withKey("revoked"), get, codes.PermissionDenied},
+		{"method not allowed", withKey("secret"), "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo", codes.PermissionDenied},
+		{"health without key", context.Background(), "/grpc.health.v1.Health/Check", codes.OK},
+		{"valid key", withKey("secret"), get, codes.OK},
+		{"rate limited", withKey("secret"), get, codes.ResourceExhausted},
+		// Rejected calls are charged to the peer IP, so guessing keys is throttled
+		{"guessing throttled", withKey("guess2"), get, codes.ResourceExhausted},
+	} {
+		if code := status.Code(guard.check(tc.ctx, tc.method)); code != tc.code {
+			t.Errorf("%s: code %s, want %s", tc.name, code, tc.code)
+		}
+	}
+}
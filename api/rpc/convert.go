@@ -0,0 +1,93 @@
+// convert.go maps the REST Country model to its protobuf message.
+package rpc
+
+import (
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+	gcrv1 "github.com/DoROAD-AI/gcr/proto/gcr/v1"
+)
+
+// toProto converts a country to its protobuf message. Slices and maps are shared with the
+// dataset, which is never modified after loading.
+func toProto(c v1.Country) *gcrv1.Country {
+	out := &gcrv1.Country{
+		Name:         &gcrv1.Name{Common: c.Name.Common, Official: c.Name.Official},
+		Tld:          c.TLD,
+		Cca2:         c.CCA2,
+		Ccn3:         c.CCN3,
+		Cca3:         c.CCA3,
+		Cioc:         c.CIOC,
+		Fifa:         c.FIFA,
+		Independent:  c.Independent,
+		Status:       c.Status,
+		UnMember:     c.UNMember,
+		Idd:          &gcrv1.IDD{Root: c.IDD.Root, Suffixes: c.IDD.Suffixes},
+		Capital:      c.Capital,
+		AltSpellings: c.AltSpellings,
+		Latlng:       c.Latlng,
+		Landlocked:   c.Landlocked,
+		Borders:      c.Borders,
+		Area:         c.Area,
+		Flag:         c.Flag,
+		Region:       c.Region,
+		Subregion:    c.Subregion,
+		Maps:         &gcrv1.Maps{GoogleMaps: c.Maps.GoogleMaps, OpenStreetMaps: c.Maps.OpenStreetMaps},
+		Population:   int64(c.Population),
+		Gini:         c.Gini,
+		Car:          &gcrv1.Car{Signs: c.Car.Signs, Side: c.Car.Side},
+		Timezones:    c.Timezones,
+		Continents:   c.Continents,
+		Flags:        &gcrv1.Flags{Svg: c.Flags.Svg, Png: c.Flags.Png, Alt: c.Flags.Alt},
+		CoatOfArms:   &gcrv1.CoatOfArms{Svg: c.CoatOfArms.Svg, Png: c.CoatOfArms.Png},
+		StartOfWeek:  c.StartOfWeek,
+		CapitalInfo:  &gcrv1.CapitalInfo{Latlng: c.CapitalInfo.Latlng},
+		PostalCode:   &gcrv1.PostalCode{Format: c.PostalCode.Format, Regex: c.PostalCode.Regex},
+		Demonyms:     &gcrv1.Demonyms{Eng: &gcrv1.Demonym{F: c.Demonyms.Eng.F, M: c.Demonyms.Eng.M}},
+		Languages:    c.Languages,
+	}
+	if c.Demonyms.Fra != nil {
+		out.Demonyms.Fra = &gcrv1.Demonym{F: c.Demonyms.Fra.F, M: c.Demonyms.Fra.M}
+	}
+	if len(c.Currencies) > 0 {
+		out.Currencies = make(map[string]*gcrv1.Currency, len(c.Currencies))
+		for code, currency := range c.Currencies {
+			out.Currencies[code] = &gcrv1.Currency{Name: currency.Name, Symbol: currency.Symbol}
+		}
+	}
+	if len(c.Translations) > 0 {
+		out.Translations = make(map[string]*gcrv1.Translation, len(c.Translations))
+		for lang, translation := range c.Translations {
+			out.Translations[lang] = &gcrv1.Translation{Official: translation.Official, Common: translation.Common}
+		}
+	}
+	return out
+}
+
+// searchFilters converts a SearchRequest to the filter keys of v1.SearchCountries.
+func searchFilters(req *gcrv1.SearchRequest) map[string]string {
+	filters := make(map[string]string)
+	for key, value := range map[string]string{
+		"name":        req.GetName(),
+		"fullName":    req.GetFullName(),
+		"currency":    req.GetCurrency(),
+		"demonym":     req.GetDemonym(),
+		"language":    req.GetLanguage(),
+		"capital":     req.GetCapital(),
+		"region":      req.GetRegion(),
+		"subregion":   req.GetSubregion(),
+		"group":       req.GetGroup(),
+		"continent":   req.GetContinent(),
+		"translation": req.GetTranslation(),
+	} {
+		if value != "" {
+			filters[key] = value
+		}
+	}
+	if req.Independent != nil {
+		if req.GetIndependent() {
+			filters["independent"] = "true"
+		} else {
+			filters["independent"] = "false"
+		}
+	}
+	return filters
+}
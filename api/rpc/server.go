@@ -0,0 +1,145 @@
+// Package rpc serves the country dataset over gRPC: the CountryService defined in
+// proto/gcr/v1/country.proto, the standard health service and server reflection.
+package rpc
+
+//go:generate protoc -I ../../proto --go_out=../../proto --go_opt=paths=source_relative --go-grpc_out=../../proto --go-grpc_opt=paths=source_relative gcr/v1/country.proto
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+	gcrv1 "github.com/DoROAD-AI/gcr/proto/gcr/v1"
+)
+
+// NewServer returns a gRPC server with the CountryService, health and reflection services
+// registered. Calls are logged to logger like HTTP requests are, then checked against guard.
+func NewServer(logger *slog.Logger, guard Guard) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryLog(logger), unaryGuard(guard)),
+		grpc.ChainStreamInterceptor(streamLog(logger), streamGuard(guard)),
+	)
+	gcrv1.RegisterCountryServiceServer(server, &CountryService{})
+
+	// The dataset is loaded before the server starts, so both the server and the service
+	// are serving from the outset
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(gcrv1.CountryService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	reflection.Register(server)
+	return server
+}
+
+// CountryService implements gcrv1.CountryServiceServer over v1.Countries.
+type CountryService struct {
+	gcrv1.UnimplementedCountryServiceServer
+}
+
+// Get returns the country with the requested code.
+func (s *CountryService) Get(ctx context.Context, req *gcrv1.GetRequest) (*gcrv1.Country, error) {
+	code := strings.TrimSpace(req.GetCode())
+	if code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+	country, ok := v1.FindCountry(code)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no country matches code '%s'", code)
+	}
+	return toProto(country), nil
+}
+
+// BatchGet returns the countries for the requested codes in request order.
+func (s *CountryService) BatchGet(ctx context.Context, req *gcrv1.BatchGetRequest) (*gcrv1.BatchGetResponse, error) {
+	if len(req.GetCodes()) > v1.MaxCodes {
+		return nil, status.Errorf(codes.InvalidArgument, "%d codes given, at most %d are allowed", len(req.GetCodes()), v1.MaxCodes)
+	}
+	resp := &gcrv1.BatchGetResponse{}
+	for _, code := range req.GetCodes() {
+		if country, ok := v1.FindCountry(strings.TrimSpace(code)); ok {
+			resp.Countries = append(resp.Countries, toProto(country))
+		} else {
+			resp.NotFound = append(resp.NotFound, code)
+		}
+	}
+	return resp, nil
+}
+
+// Search returns the countries matching the request filters. Unlike the REST search routes, no
+// match is an empty response rather than NOT_FOUND.
+func (s *CountryService) Search(ctx context.Context, req *gcrv1.SearchRequest) (*gcrv1.SearchResponse, error) {
+	countries, err := v1.SearchCountries(ctx, searchFilters(req))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp := &gcrv1.SearchResponse{Countries: make([]*gcrv1.Country, 0, len(countries))}
+	for _, country := range countries {
+		resp.Countries = append(resp.Countries, toProto(country))
+	}
+	return resp, nil
+}
+
+// List streams the countries matching the request filter, one message per country.
+func (s *CountryService) List(req *gcrv1.ListRequest, stream grpc.ServerStreamingServer[gcrv1.Country]) error {
+	countries := v1.Countries
+	if filter := req.GetFilter(); filter != nil {
+		var err error
+		if countries, err = v1.SearchCountries(stream.Context(), searchFilters(filter)); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	for _, country := range countries {
+		if err := stream.Send(toProto(country)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unaryLog logs every unary call with its status code and latency.
+func unaryLog(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, logger, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// streamLog logs every streaming call once it ends.
+func streamLog(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(ss.Context(), logger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// logCall writes the access log record of a call; server-side failures are logged at error
+// level and client errors at warn, as for HTTP.
+func logCall(ctx context.Context, logger *slog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+	logger.LogAttrs(ctx, level, "grpc request",
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+	)
+}
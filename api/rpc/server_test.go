@@ -0,0 +1,216 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/DoROAD-AI/gcr/api/v1"
+	gcrv1 "github.com/DoROAD-AI/gcr/proto/gcr/v1"
+)
+
+var (
+	loadDataOnce sync.Once
+	loadDataErr  error
+)
+
+// testClient serves the bundled dataset, loaded once per test binary, over an in-memory
+// connection without a guard and returns a client for it.
+func testClient(t *testing.T) gcrv1.CountryServiceClient {
+	t.Helper()
+	loadDataOnce.Do(func() {
+		if loadDataErr = v1.LoadCountriesSafe("../../data/countries.json"); loadDataErr != nil {
+			return
+		}
+		if loadDataErr = v1.LoadLanguagesSafe("../../data/languages.json"); loadDataErr != nil {
+			return
+		}
+		loadDataErr = v1.LoadGroupsSafe("../../data/groups.json")
+	})
+	if loadDataErr != nil {
+		t.Fatal(loadDataErr)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(slog.New(slog.NewTextHandler(io.Discard, nil)), Guard{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return gcrv1.NewCountryServiceClient(conn)
+}
+
+// cca3s returns the sorted cca3 codes of countries.
+func cca3s(countries []*gcrv1.Country) []string {
+	codes := make([]string, len(countries))
+	for i, country := range countries {
+		codes[i] = country.GetCca3()
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func TestCountryServiceGet(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	for _, code := range []string{"DE", "deu", "276"} {
+		country, err := client.Get(ctx, &gcrv1.GetRequest{Code: code})
+		if err != nil {
+			t.Fatalf("Get(%s): %v", code, err)
+		}
+		if country.GetCca3() != "DEU" || country.GetName().GetCommon() != "Germany" || country.GetCurrencies()["EUR"].GetName() != "Euro" {
+			t.Errorf("Get(%s) = %s %q", code, country.GetCca3(), country.GetName().GetCommon())
+		}
+	}
+
+	for code, want := range map[string]codes.Code{
+		"":    codes.InvalidArgument,
+		"  ":  codes.InvalidArgument,
+		"ZZZ": codes.NotFound,
+	} {
+		if _, err := client.Get(ctx, &gcrv1.GetRequest{Code: code}); status.Code(err) != want {
+			t.Errorf("Get(%q): %v, want %s", code, err, want)
+		}
+	}
+}
+
+func TestCountryServiceBatchGet(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	resp, err := client.BatchGet(ctx, &gcrv1.BatchGetRequest{Codes: []string{"FR", "ZZZ", "deu", "QQ"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := []string{resp.GetCountries()[0].GetCca3(), resp.GetCountries()[1].GetCca3()}; len(resp.GetCountries()) != 2 || got[0] != "FRA" || got[1] != "DEU" {
+		t.Errorf("countries %v, want FRA and DEU in request order", cca3s(resp.GetCountries()))
+	}
+	if notFound := resp.GetNotFound(); len(notFound) != 2 || notFound[0] != "ZZZ" || notFound[1] != "QQ" {
+		t.Errorf("not_found %q, want [ZZZ QQ]", notFound)
+	}
+
+	defer func(max int) { v1.MaxCodes = max }(v1.MaxCodes)
+	v1.MaxCodes = 2
+	if _, err := client.BatchGet(ctx, &gcrv1.BatchGetRequest{Codes: []string{"FR", "DE", "IT"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("too many codes: %v, want InvalidArgument", err)
+	}
+}
+
+func TestCountryServiceSearch(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name string
+		req  *gcrv1.SearchRequest
+		want map[string]string
+	}{
+		{"name", &gcrv1.SearchRequest{Name: "germany"}, map[string]string{"name": "germany"}},
+		{"full name", &gcrv1.SearchRequest{FullName: "Germany"}, map[string]string{"fullName": "Germany"}},
+		{"currency and region", &gcrv1.SearchRequest{Currency: "EUR", Region: "Europe"}, map[string]string{"currency": "EUR", "region": "Europe"}},
+		{"language", &gcrv1.SearchRequest{Language: "deu"}, map[string]string{"language": "deu"}},
+		{"group", &gcrv1.SearchRequest{Group: "EU"}, map[string]string{"group": "EU"}},
+		{"continent and subregion", &gcrv1.SearchRequest{Continent: "Europe", Subregion: "Western Europe"}, map[string]string{"continent": "Europe", "subregion": "Western Europe"}},
+		{"demonym, capital, translation", &gcrv1.SearchRequest{Demonym: "German", Capital: "Berlin", Translation: "Allemagne"}, map[string]string{"demonym": "German", "capital": "Berlin", "translation": "Allemagne"}},
+		{"not independent", &gcrv1.SearchRequest{Independent: proto.Bool(false)}, map[string]string{"independent": "false"}},
+		{"independent", &gcrv1.SearchRequest{Independent: proto.Bool(true), Region: "Oceania"}, map[string]string{"independent": "true", "region": "Oceania"}},
+	} {
+		resp, err := client.Search(ctx, tc.req)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		want, err := v1.SearchCountries(ctx, tc.want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(want) == 0 {
+			t.Fatalf("%s: REST filters %v match nothing", tc.name, tc.want)
+		}
+		wantCodes := make([]string, len(want))
+		for i, country := range want {
+			wantCodes[i] = country.CCA3
+		}
+		sort.Strings(wantCodes)
+		if got := cca3s(resp.GetCountries()); !slices.Equal(got, wantCodes) {
+			t.Errorf("%s: %v, want %v", tc.name, got, wantCodes)
+		}
+	}
+
+	// No match is an empty response, an unknown group an invalid argument
+	resp, err := client.Search(ctx, &gcrv1.SearchRequest{Name: "atlantis"})
+	if err != nil || len(resp.GetCountries()) != 0 {
+		t.Errorf("no match: %d countries, error %v; want an empty response", len(resp.GetCountries()), err)
+	}
+	if _, err := client.Search(ctx, &gcrv1.SearchRequest{Group: "NOPE"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown group: %v, want InvalidArgument", err)
+	}
+}
+
+func TestCountryServiceList(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	receive := func(req *gcrv1.ListRequest) ([]*gcrv1.Country, error) {
+		stream, err := client.List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		var countries []*gcrv1.Country
+		for {
+			country, err := stream.Recv()
+			if err == io.EOF {
+				return countries, nil
+			}
+			if err != nil {
+				return countries, err
+			}
+			countries = append(countries, country)
+		}
+	}
+
+	countries, err := receive(&gcrv1.ListRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(countries) != len(v1.Countries) {
+		t.Fatalf("streamed %d countries, want %d", len(countries), len(v1.Countries))
+	}
+	for i, country := range countries {
+		if country.GetCca3() != v1.Countries[i].CCA3 {
+			t.Fatalf("message %d is %s, want %s", i, country.GetCca3(), v1.Countries[i].CCA3)
+		}
+	}
+
+	countries, err = receive(&gcrv1.ListRequest{Filter: &gcrv1.SearchRequest{Region: "Europe", Group: "EU"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(countries) != 27 {
+		t.Errorf("EU members in Europe: streamed %d countries, want 27", len(countries))
+	}
+
+	if _, err := receive(&gcrv1.ListRequest{Filter: &gcrv1.SearchRequest{Group: "NOPE"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown group: %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,245 @@
+// batch.go contains the batch lookup endpoint, which answers many code and search lookups in
+// one request with a result per lookup.
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MaxBatchLookups caps the number of lookups in one batch request.
+var MaxBatchLookups = 500
+
+// maxBatchLookupBytes is the body size allowed per lookup; a batch body may be MaxBatchLookups
+// times this long.
+const maxBatchLookupBytes = 1 << 10
+
+// maxBatchBody returns the size limit of a batch body.
+func maxBatchBody() int64 {
+	return int64(MaxBatchLookups) * maxBatchLookupBytes
+}
+
+// BatchLookup is one lookup of a batch request. Exactly one lookup member must be set; each
+// behaves like the route of the same name.
+type BatchLookup struct {
+	Alpha       string `json:"alpha,omitempty" example:"DE"`
+	CCN3        string `json:"ccn3,omitempty" example:"276"`
+	Name        string `json:"name,omitempty" example:"Japan"`
+	FullText    bool   `json:"fullText,omitempty" example:"true"`
+	CallingCode string `json:"callingcode,omitempty" example:"44"`
+	Currency    string `json:"currency,omitempty" example:"EUR"`
+	Demonym     string `json:"demonym,omitempty" example:"German"`
+	Lang        string `json:"lang,omitempty" example:"spa"`
+	Capital     string `json:"capital,omitempty" example:"Tallinn"`
+	Region      string `json:"region,omitempty" example:"Europe"`
+	Subregion   string `json:"subregion,omitempty" example:"Northern Europe"`
+	Continent   string `json:"continent,omitempty" example:"South America"`
+	Translation string `json:"translation,omitempty" example:"Saksamaa"`
+}
+
+// BatchResult is the outcome of one lookup, at the position of the lookup in the request. Data is
+// a country for alpha and ccn3 lookups and an array of countries for the others; Error is set
+// instead when the lookup failed.
+type BatchResult struct {
+	Status int         `json:"status" example:"200"`
+	Data   interface{} `json:"data,omitempty" swaggertype:"object"`
+	Error  *Problem    `json:"error,omitempty"`
+}
+
+// lookupKey returns the single lookup member that is set and its value.
+func (l BatchLookup) lookupKey() (string, string, error) {
+	var key, value string
+	for _, member := range []struct{ key, value string }{
+		{"alpha", l.Alpha}, {"ccn3", l.CCN3}, {"name", l.Name}, {"callingcode", l.CallingCode},
+		{"currency", l.Currency}, {"demonym", l.Demonym}, {"lang", l.Lang}, {"capital", l.Capital},
+		{"region", l.Region}, {"subregion", l.Subregion}, {"continent", l.Continent},
+		{"translation", l.Translation},
+	} {
+		if member.value == "" {
+			continue
+		}
+		if key != "" {
+			return "", "", fmt.Errorf("lookup sets both '%s' and '%s'; use one per lookup", key, member.key)
+		}
+		key, value = member.key, member.value
+	}
+	if key == "" {
+		return "", "", fmt.Errorf("lookup sets none of alpha, ccn3, name, callingcode, currency, demonym, lang, capital, region, subregion, continent or translation")
+	}
+	if l.FullText && key != "name" {
+		return "", "", fmt.Errorf("fullText only applies to name lookups")
+	}
+	return key, value, nil
+}
+
+// filterKeys maps lookup members to their filterCountries keys.
+var filterKeys = map[string]string{
+	"currency":    "currency",
+	"demonym":     "demonym",
+	"lang":        "language",
+	"capital":     "capital",
+	"region":      "region",
+	"subregion":   "subregion",
+	"continent":   "continent",
+	"translation": "translation",
+}
+
+// errNotBatch is returned by decodeBatch for bodies that are not a JSON array.
+var errNotBatch = errors.New("body must be a JSON array of lookups")
+
+// decodeBatch reads the lookups of a batch body one by one, failing as soon as there are more
+// than MaxBatchLookups rather than decoding the whole array first. Lookups are returned undecoded
+// so each can fail on its own.
+func decodeBatch(body io.Reader) ([]json.RawMessage, error) {
+	dec := json.NewDecoder(body)
+	if token, err := dec.Token(); err != nil {
+		return nil, bodyError(err)
+	} else if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errNotBatch
+	}
+
+	var items []json.RawMessage
+	for dec.More() {
+		if len(items) == MaxBatchLookups {
+			return nil, fmt.Errorf("more than %d lookups given", MaxBatchLookups)
+		}
+		var item json.RawMessage
+		if err := dec.Decode(&item); err != nil {
+			return nil, bodyError(err)
+		}
+		items = append(items, item)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, bodyError(err)
+	}
+	return items, nil
+}
+
+// bodyError keeps size limit errors for the caller and reports anything else as errNotBatch.
+func bodyError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return err
+	}
+	return errNotBatch
+}
+
+// PostBatch godoc
+// @Summary     Batch lookup
+// @Description Run up to validation.max_batch lookups of different kinds in one request, e.g. [{"alpha":"DE"},{"name":"Japan","fullText":true},{"callingcode":"44"},{"currency":"EUR"}]. The response has one result per lookup in request order, with the status the equivalent route would return and either data or a problem. fields and lang apply to every lookup.
+// @Tags        Countries
+// @Accept      json
+// @Produce     json
+// @Param       lookups body  []BatchLookup true  "Lookups, each setting exactly one lookup member"
+// @Param       fields  query string        false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang    query string        false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Success     200 {array}  BatchResult
+// @Failure     400 {object} Problem
+// @Failure     413 {object} Problem
+// @Router      /batch [post]
+func PostBatch(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBody())
+	items, err := decodeBatch(c.Request.Body)
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		respondProblem(c, http.StatusRequestEntityTooLarge, CodeBodyTooLarge, "body",
+			fmt.Sprintf("body exceeds %d bytes", maxBatchBody()))
+		return
+	case err != nil:
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "body", err.Error())
+		return
+	}
+
+	key, tag, err := negotiateLanguage(c)
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "lang", err.Error())
+		return
+	}
+	c.Header("Content-Language", tag.String())
+
+	var projection *compiledProjection
+	if fields := c.Query("fields"); fields != "" {
+		projection = projectionFor(fields)
+	}
+
+	ctx, span := tracer.Start(c.Request.Context(), "batch", trace.WithAttributes(attribute.Int("gcr.lookups", len(items))))
+	defer span.End()
+
+	results := make([]BatchResult, len(items))
+	for i, item := range items {
+		var lookup BatchLookup
+		dec := json.NewDecoder(bytes.NewReader(item))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&lookup); err != nil {
+			results[i] = batchError(c, http.StatusBadRequest, CodeInvalidParameter, "", fmt.Sprintf("lookup %d is not a valid lookup object: %v", i+1, err))
+			continue
+		}
+		param, value, err := lookup.lookupKey()
+		if err != nil {
+			results[i] = batchError(c, http.StatusBadRequest, CodeInvalidParameter, "", fmt.Sprintf("lookup %d: %v", i+1, err))
+			continue
+		}
+
+		switch param {
+		case "alpha", "ccn3":
+			find := FindCountry
+			if param == "ccn3" {
+				find = findCountryByCCN3
+			}
+			country, ok := find(value)
+			if !ok {
+				results[i] = batchError(c, http.StatusNotFound, CodeCountryNotFound, param, fmt.Sprintf("No country matches %s '%s'", param, value))
+				continue
+			}
+			country = localizeCountry(country, key)
+			results[i] = BatchResult{Status: http.StatusOK, Data: country}
+			if projection != nil {
+				results[i].Data = projection.apply(&country)
+			}
+		default:
+			var matches []Country
+			switch param {
+			case "name":
+				filterKey := "name"
+				if lookup.FullText {
+					filterKey = "fullName"
+				}
+				matches = filterCountries(ctx, map[string]string{filterKey: value})
+			case "callingcode":
+				matches = findCountriesByCallingCode(strings.TrimPrefix(value, "+"))
+			default:
+				matches = filterCountries(ctx, map[string]string{filterKeys[param]: value})
+			}
+			if len(matches) == 0 {
+				results[i] = batchError(c, http.StatusNotFound, CodeCountryNotFound, param, fmt.Sprintf("No country matches %s '%s'", param, value))
+				continue
+			}
+			matches = localizeCountries(matches, key, tag)
+			results[i] = BatchResult{Status: http.StatusOK, Data: matches}
+			if projection != nil {
+				projected := make([]map[string]interface{}, 0, len(matches))
+				for j := range matches {
+					projected = append(projected, projection.apply(&matches[j]))
+				}
+				results[i].Data = projected
+			}
+		}
+	}
+	respondData(c, results, len(results), len(Countries))
+}
+
+// batchError builds the failed result of a lookup; param names the lookup member.
+func batchError(c *gin.Context, status int, code ErrorCode, param, detail string) BatchResult {
+	problem := newProblem(c, status, code, param, detail)
+	return BatchResult{Status: status, Error: &problem}
+}
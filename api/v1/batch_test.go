@@ -0,0 +1,182 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// batchRequest posts body to the batch handler and returns the recorded response.
+func batchRequest(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/batch", PostBatch)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPostBatchLimits(t *testing.T) {
+	loadTestCountries(t)
+	defer func(max int) { MaxBatchLookups = max }(MaxBatchLookups)
+	MaxBatchLookups = 3
+
+	lookups := func(n int) string {
+		return "[" + strings.TrimSuffix(strings.Repeat(`{"alpha":"DE"},`, n), ",") + "]"
+	}
+	for _, tc := range []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"at the limit", lookups(3), http.StatusOK},
+		{"one lookup too many", lookups(4), http.StatusBadRequest},
+		// Far more lookups than allowed fail on the count before the body is read to the end
+		{"many lookups, truncated", strings.TrimSuffix(lookups(4), "]") + strings.Repeat(" ", int(maxBatchBody())), http.StatusBadRequest},
+		{"oversized lookup", `[{"name":"` + strings.Repeat("x", int(maxBatchBody())) + `"}]`, http.StatusRequestEntityTooLarge},
+		{"not an array", `{"alpha":"DE"}`, http.StatusBadRequest},
+		{"unterminated array", `[{"alpha":"DE"}`, http.StatusBadRequest},
+	} {
+		if w := batchRequest(t, tc.body); w.Code != tc.status {
+			t.Errorf("%s: status %d, want %d: %s", tc.name, w.Code, tc.status, w.Body)
+		}
+	}
+}
+
+// batchResults posts body to the batch handler at target and decodes the results.
+func batchResults(t *testing.T, target, body string) []map[string]json.RawMessage {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := serve("/batch", PostBatch, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var results []map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	return results
+}
+
+func TestPostBatchMixedLookups(t *testing.T) {
+	loadTestCountries(t)
+
+	results := batchResults(t, "/batch", `[
+		{"alpha": "DE"},
+		{"name": "Japan", "fullText": true},
+		{"alpha": "ZZZ"},
+		{"ccn3": "250"},
+		{"callingcode": "+44"},
+		{"currency": "EUR", "region": "Europe"},
+		{"capital": "Tallinn"},
+		{"unknown": "x"},
+		{"lang": "spa"}
+	]`)
+
+	for i, want := range []struct {
+		status int
+		object bool
+		cca3   string
+		code   ErrorCode
+	}{
+		{http.StatusOK, true, "DEU", ""},
+		{http.StatusOK, false, "JPN", ""},
+		{http.StatusNotFound, false, "", CodeCountryNotFound},
+		{http.StatusOK, true, "FRA", ""},
+		{http.StatusOK, false, "GBR", ""},
+		{http.StatusBadRequest, false, "", CodeInvalidParameter},
+		{http.StatusOK, false, "EST", ""},
+		{http.StatusBadRequest, false, "", CodeInvalidParameter},
+		{http.StatusOK, false, "ESP", ""},
+	} {
+		if i >= len(results) {
+			t.Fatalf("%d results, want 9", len(results))
+		}
+		result := results[i]
+		var status int
+		if err := json.Unmarshal(result["status"], &status); err != nil || status != want.status {
+			t.Errorf("result %d: status %s, want %d", i, result["status"], want.status)
+			continue
+		}
+		if want.code != "" {
+			var problem Problem
+			if err := json.Unmarshal(result["error"], &problem); err != nil || problem.Code != want.code {
+				t.Errorf("result %d: error %s, want %s", i, result["error"], want.code)
+			}
+			if _, ok := result["data"]; ok {
+				t.Errorf("result %d: failed lookup carries data", i)
+			}
+			continue
+		}
+		if _, ok := result["error"]; ok {
+			t.Errorf("result %d: successful lookup carries an error", i)
+		}
+
+		// alpha and ccn3 answer with a country, the other lookups with an array
+		var codes []string
+		if want.object {
+			var country Country
+			if err := json.Unmarshal(result["data"], &country); err != nil {
+				t.Errorf("result %d: data %.40s is not an object: %v", i, result["data"], err)
+				continue
+			}
+			codes = []string{country.CCA3}
+		} else {
+			var countries []Country
+			if err := json.Unmarshal(result["data"], &countries); err != nil {
+				t.Errorf("result %d: data %.40s is not an array: %v", i, result["data"], err)
+				continue
+			}
+			for _, country := range countries {
+				codes = append(codes, country.CCA3)
+			}
+		}
+		if !containsString(codes, want.cca3) {
+			t.Errorf("result %d: countries %v, want %s among them", i, codes, want.cca3)
+		}
+	}
+	if len(results) != 9 {
+		t.Errorf("%d results, want 9", len(results))
+	}
+}
+
+func TestPostBatchAppliesFieldsToEveryItem(t *testing.T) {
+	loadTestCountries(t)
+
+	results := batchResults(t, "/batch?fields=cca3,name.common", `[{"alpha": "DE"}, {"region": "Oceania"}, {"ccn3": "392"}]`)
+	if len(results) != 3 {
+		t.Fatalf("%d results, want 3", len(results))
+	}
+
+	var documents []map[string]interface{}
+	for i, result := range results {
+		data := result["data"]
+		if i == 1 {
+			var list []map[string]interface{}
+			if err := json.Unmarshal(data, &list); err != nil || len(list) == 0 {
+				t.Fatalf("result %d: data %.40s, want a non-empty array", i, data)
+			}
+			documents = append(documents, list...)
+			continue
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("result %d: data %.40s, want an object", i, data)
+		}
+		documents = append(documents, doc)
+	}
+	for _, doc := range documents {
+		name, _ := doc["name"].(map[string]interface{})
+		if len(doc) != 2 || doc["cca3"] == nil || len(name) != 1 || name["common"] == nil {
+			t.Errorf("projected country %v, want only cca3 and name.common", doc)
+		}
+	}
+}
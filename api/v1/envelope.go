@@ -0,0 +1,127 @@
+// envelope.go contains the optional response envelope, which wraps a result with its count,
+// the dataset version and the request that produced it.
+package v1
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnvelopeContentType is the media type of enveloped responses. Clients opt into the envelope
+// by listing it in Accept or with envelope=true; bare results stay the default.
+const EnvelopeContentType = "application/vnd.gcr+json"
+
+// Envelope wraps a successful response body in envelope mode.
+type Envelope struct {
+	Data  interface{}   `json:"data" swaggertype:"object"`
+	Meta  EnvelopeMeta  `json:"meta"`
+	Links EnvelopeLinks `json:"links"`
+}
+
+// EnvelopeMeta describes an enveloped result.
+type EnvelopeMeta struct {
+	// Count is the number of entries in data, 1 for a single object.
+	Count int `json:"count" example:"2"`
+	// Total is the size of the collection the route looks in, e.g. every country of the dataset.
+	Total          int       `json:"total" example:"250"`
+	DatasetVersion string    `json:"datasetVersion" example:"3f5a..."`
+	GeneratedAt    time.Time `json:"generatedAt"`
+	// Query echoes the path and query parameters of the request; repeated query parameters are
+	// joined with commas.
+	Query map[string]string `json:"query"`
+	// NotFound lists the requested codes of /alpha that match no country.
+	NotFound []string `json:"notFound,omitempty"`
+}
+
+// EnvelopeLinks holds the links of an enveloped result.
+type EnvelopeLinks struct {
+	Self string `json:"self" example:"/v1/alpha?codes=DE,FR"`
+}
+
+// AcceptsEnvelope reports whether the Accept header asks for the envelope media type.
+func AcceptsEnvelope(r *http.Request) bool {
+	return acceptsMediaType(r.Header.Values("Accept"), EnvelopeContentType)
+}
+
+// acceptsMediaType reports whether the Accept header values list mediaType itself, not a
+// wildcard, with a non-zero quality. Media type parameters other than q are ignored, so
+// "application/vnd.gcr+json;charset=utf-8" matches and "application/vnd.gcr+json;q=0" does not.
+func acceptsMediaType(values []string, mediaType string) bool {
+	for _, value := range values {
+		for _, mediaRange := range strings.Split(value, ",") {
+			params := strings.Split(mediaRange, ";")
+			if !strings.EqualFold(strings.TrimSpace(params[0]), mediaType) {
+				continue
+			}
+			quality := 1.0
+			for _, param := range params[1:] {
+				name, q, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(name), "q") {
+					continue
+				}
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(q), 64)
+				if err != nil {
+					parsed = 0
+				}
+				quality = parsed
+			}
+			if quality > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wantsEnvelope reports whether the response should be enveloped, either per the envelope
+// parameter or the Accept header.
+func wantsEnvelope(c *gin.Context) (bool, error) {
+	value, err := validateBooleanQuery(c.Query("envelope"))
+	if err != nil {
+		return false, err
+	}
+	return value == "true" || value == "" && AcceptsEnvelope(c.Request), nil
+}
+
+// respondData writes a successful response, enveloped when the request asks for it. count is
+// the number of entries in data and total the size of the collection the route looks in.
+func respondData(c *gin.Context, data interface{}, count, total int) {
+	envelope, err := wantsEnvelope(c)
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "envelope", err.Error())
+		return
+	}
+	if !envelope {
+		c.JSON(http.StatusOK, data)
+		return
+	}
+
+	query := make(map[string]string)
+	for _, param := range c.Params {
+		query[param.Key] = param.Value
+	}
+	for key, values := range c.Request.URL.Query() {
+		query[key] = strings.Join(values, ",")
+	}
+	meta := EnvelopeMeta{
+		Count:          count,
+		Total:          total,
+		DatasetVersion: DatasetChecksum,
+		GeneratedAt:    time.Now().UTC(),
+		Query:          query,
+	}
+	if notFound := c.Writer.Header().Get(NotFoundHeader); notFound != "" {
+		meta.NotFound = strings.Split(notFound, ",")
+	}
+
+	c.Header("Content-Type", EnvelopeContentType+"; charset=utf-8")
+	c.JSON(http.StatusOK, Envelope{
+		Data:  data,
+		Meta:  meta,
+		Links: EnvelopeLinks{Self: c.Request.URL.RequestURI()},
+	})
+}
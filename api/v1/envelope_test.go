@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAcceptsEnvelope(t *testing.T) {
+	for accept, want := range map[string]bool{
+		"":                                       false,
+		"application/json":                       false,
+		"*/*":                                    false,
+		"application/*":                          false,
+		"application/vnd.gcr+json":               true,
+		"Application/VND.GCR+JSON":               true,
+		"application/vnd.gcr+json;charset=utf-8": true,
+		"application/json, application/vnd.gcr+json;q=0.5":  true,
+		"application/vnd.gcr+json;q=0":                      false,
+		"application/vnd.gcr+json; q=0.0, application/json": false,
+		"application/vnd.gcr+jsonx":                         false,
+		"application/vnd.gcr+json-seq":                      false,
+		"text/html;note=application/vnd.gcr+json":           false,
+	} {
+		r := httptest.NewRequest("GET", "/v1/all", nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		if got := AcceptsEnvelope(r); got != want {
+			t.Errorf("Accept %q: AcceptsEnvelope = %v, want %v", accept, got, want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// errors.go contains the RFC 7807 problem details error model shared by all handlers and middleware.
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of problem details responses.
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBase prefixes the error code to form the problem type URI, which documents the code.
+const ProblemTypeBase = "https://github.com/DoROAD-AI/gcr/blob/main/ERRORS.md#"
+
+// ErrorCode is a stable, machine-readable error identifier. Codes are never renamed or reused.
+type ErrorCode string
+
+// Error codes returned in the code member of a Problem.
+const (
+	CodeInvalidParameter ErrorCode = "invalid_parameter"
+	CodeMissingParameter ErrorCode = "missing_parameter"
+	CodeBodyTooLarge     ErrorCode = "body_too_large"
+	CodeValidationFailed ErrorCode = "validation_failed"
+	CodeCountryNotFound  ErrorCode = "country_not_found"
+	CodeLanguageNotFound ErrorCode = "language_not_found"
+	CodeRegionNotFound   ErrorCode = "region_not_found"
+	CodeGroupNotFound    ErrorCode = "group_not_found"
+	CodeLocaleNotMatched ErrorCode = "locale_not_matched"
+	CodeRouteNotFound    ErrorCode = "route_not_found"
+	CodeMissingAPIKey    ErrorCode = "missing_api_key"
+	CodeInvalidAPIKey    ErrorCode = "invalid_api_key"
+	CodeAPIKeyDisabled   ErrorCode = "api_key_disabled"
+	CodeRouteNotAllowed  ErrorCode = "route_not_allowed"
+	CodeRateLimited      ErrorCode = "rate_limited"
+	CodeInternalError    ErrorCode = "internal_error"
+	CodeNotReady         ErrorCode = "not_ready"
+)
+
+// problemTitles holds the fixed, human-readable summary of each code.
+var problemTitles = map[ErrorCode]string{
+	CodeInvalidParameter: "Invalid parameter",
+	CodeMissingParameter: "Missing parameter",
+	CodeBodyTooLarge:     "Request body too large",
+	CodeValidationFailed: "Request validation failed",
+	CodeCountryNotFound:  "Country not found",
+	CodeLanguageNotFound: "Language not found",
+	CodeRegionNotFound:   "Region not found",
+	CodeGroupNotFound:    "Group not found",
+	CodeLocaleNotMatched: "Locale not matched",
+	CodeRouteNotFound:    "Route not found",
+	CodeMissingAPIKey:    "Missing API key",
+	CodeInvalidAPIKey:    "Invalid API key",
+	CodeAPIKeyDisabled:   "API key disabled",
+	CodeRouteNotAllowed:  "Route not allowed",
+	CodeRateLimited:      "Rate limit exceeded",
+	CodeInternalError:    "Internal server error",
+	CodeNotReady:         "Service not ready",
+}
+
+// Problem is an RFC 7807 problem details object, extended with the error code and the
+// offending request parameter.
+type Problem struct {
+	Type     string    `json:"type" example:"https://github.com/DoROAD-AI/gcr/blob/main/ERRORS.md#invalid_parameter"`
+	Title    string    `json:"title" example:"Invalid parameter"`
+	Status   int       `json:"status" example:"400"`
+	Detail   string    `json:"detail,omitempty" example:"invalid boolean value: yes (must be 'true' or 'false')"`
+	Instance string    `json:"instance,omitempty" example:"/v1/independent"`
+	Code     ErrorCode `json:"code" example:"invalid_parameter"`
+	Param    string    `json:"param,omitempty" example:"status"`
+	// InvalidParams lists every rejected parameter of a validation_failed problem.
+	InvalidParams []InvalidParam `json:"invalidParams,omitempty"`
+}
+
+// InvalidParam describes one rejected parameter, with the closest valid name when the value
+// looks like a typo.
+type InvalidParam struct {
+	Param      string `json:"param" example:"fields"`
+	Reason     string `json:"reason" example:"unknown field 'populaton'"`
+	Suggestion string `json:"suggestion,omitempty" example:"population"`
+}
+
+// LegacyErrors switches error responses back to the pre-problem-details ErrorResponse shape
+// ({"message": detail}) for clients that have not migrated. Status codes are unaffected.
+var LegacyErrors bool
+
+// newProblem builds the problem for a code; param names the offending path or query parameter.
+func newProblem(c *gin.Context, status int, code ErrorCode, param, detail string) Problem {
+	return Problem{
+		Type:     ProblemTypeBase + string(code),
+		Title:    problemTitles[code],
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Code:     code,
+		Param:    param,
+	}
+}
+
+// wantsLegacy reports whether the error should use the legacy shape. With LegacyErrors set,
+// clients can still opt into problem details per request via the Accept header.
+func wantsLegacy(c *gin.Context) bool {
+	return LegacyErrors && !strings.Contains(c.GetHeader("Accept"), ProblemContentType)
+}
+
+// respondProblem writes an error response in the configured shape.
+func respondProblem(c *gin.Context, status int, code ErrorCode, param, detail string) {
+	writeProblem(c, newProblem(c, status, code, param, detail))
+}
+
+// writeProblem writes problem, or its detail in the legacy shape.
+func writeProblem(c *gin.Context, problem Problem) {
+	if wantsLegacy(c) {
+		c.JSON(problem.Status, ErrorResponse{Message: problem.Detail})
+		return
+	}
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+// AbortWithProblem writes an error response in the configured shape and stops the handler chain.
+// It is used by middleware.
+func AbortWithProblem(c *gin.Context, status int, code ErrorCode, param, detail string) {
+	respondProblem(c, status, code, param, detail)
+	c.Abort()
+}
+
+// AbortWithInvalidParams rejects the request with a 400 validation_failed problem listing
+// every invalid parameter. The detail joins the reasons, so legacy clients see them too.
+func AbortWithInvalidParams(c *gin.Context, params []InvalidParam) {
+	reasons := make([]string, len(params))
+	for i, p := range params {
+		reasons[i] = p.Reason
+		if p.Suggestion != "" {
+			reasons[i] += " (did you mean '" + p.Suggestion + "'?)"
+		}
+	}
+
+	problem := newProblem(c, http.StatusBadRequest, CodeValidationFailed, "", strings.Join(reasons, "; "))
+	if len(params) == 1 {
+		problem.Param = params[0].Param
+	}
+	problem.InvalidParams = params
+	writeProblem(c, problem)
+	c.Abort()
+}
+
+// RouteNotFound answers requests that match no route.
+func RouteNotFound(c *gin.Context) {
+	respondProblem(c, http.StatusNotFound, CodeRouteNotFound, "", "No route matches "+c.Request.Method+" "+c.Request.URL.Path)
+}
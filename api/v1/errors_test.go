@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// decodeProblem checks that w holds a problem details response with status and code and
+// returns it.
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder, status int, code ErrorCode) Problem {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("status %d, want %d: %s", w.Code, status, w.Body)
+	}
+	if got := w.Header().Get("Content-Type"); got != ProblemContentType {
+		t.Errorf("Content-Type %q, want %s", got, ProblemContentType)
+	}
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Status != status || problem.Code != code {
+		t.Errorf("problem status %d and code %s, want %d and %s", problem.Status, problem.Code, status, code)
+	}
+	if problem.Type != ProblemTypeBase+string(code) || problem.Title != problemTitles[code] {
+		t.Errorf("problem type %q and title %q, want %q and %q", problem.Type, problem.Title, ProblemTypeBase+string(code), problemTitles[code])
+	}
+	return problem
+}
+
+func TestRespondProblem(t *testing.T) {
+	handler := func(c *gin.Context) {
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "independent", "invalid boolean value: yes")
+	}
+	w := serve("/v1/independent", handler, httptest.NewRequest(http.MethodGet, "/v1/independent?independent=yes", nil))
+
+	problem := decodeProblem(t, w, http.StatusBadRequest, CodeInvalidParameter)
+	if problem.Detail != "invalid boolean value: yes" || problem.Param != "independent" || problem.Instance != "/v1/independent" {
+		t.Errorf("problem detail %q, param %q, instance %q", problem.Detail, problem.Param, problem.Instance)
+	}
+}
+
+func TestLegacyErrors(t *testing.T) {
+	defer func(legacy bool) { LegacyErrors = legacy }(LegacyErrors)
+	LegacyErrors = true
+
+	handler := func(c *gin.Context) {
+		respondProblem(c, http.StatusNotFound, CodeCountryNotFound, "code", "No country matches code 'zz'")
+	}
+
+	w := serve("/v1/alpha/:code", handler, httptest.NewRequest(http.MethodGet, "/v1/alpha/zz", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("legacy: status %d, want 404", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json; charset=utf-8" {
+		t.Errorf("legacy: Content-Type %q", got)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body) != 1 || body["message"] != "No country matches code 'zz'" {
+		t.Errorf("legacy: body %s, want only the message", w.Body)
+	}
+
+	// Clients opt back into problem details per request
+	for _, accept := range []string{ProblemContentType, "application/json, application/problem+json;q=0.9"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/alpha/zz", nil)
+		req.Header.Set("Accept", accept)
+		w = serve("/v1/alpha/:code", handler, req)
+		decodeProblem(t, w, http.StatusNotFound, CodeCountryNotFound)
+	}
+
+	LegacyErrors = false
+	w = serve("/v1/alpha/:code", handler, httptest.NewRequest(http.MethodGet, "/v1/alpha/zz", nil))
+	decodeProblem(t, w, http.StatusNotFound, CodeCountryNotFound)
+}
+
+func TestRouteNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.NoRoute(RouteNotFound)
+	router.GET("/v1/all", GetCountries)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/nowhere", nil))
+	problem := decodeProblem(t, w, http.StatusNotFound, CodeRouteNotFound)
+	if problem.Detail != "No route matches GET /v1/nowhere" {
+		t.Errorf("detail %q", problem.Detail)
+	}
+}
+
+func TestSearchRoutesReportNoMatch(t *testing.T) {
+	loadTestCountries(t)
+
+	for _, tc := range []struct {
+		path, target string
+		handler      gin.HandlerFunc
+		param        string
+	}{
+		{"/v1/currency/:currency", "/v1/currency/xyz", GetCountriesByCurrency, "currency"},
+		{"/v1/lang/:language", "/v1/lang/klingon", GetCountriesByLanguage, "language"},
+		{"/v1/demonym/:demonym", "/v1/demonym/martian", GetCountriesByDemonym, "demonym"},
+		{"/v1/capital/:capital", "/v1/capital/atlantis", GetCountriesByCapital, "capital"},
+	} {
+		w := serve(tc.path, tc.handler, httptest.NewRequest(http.MethodGet, tc.target, nil))
+		problem := decodeProblem(t, w, http.StatusNotFound, CodeCountryNotFound)
+		if problem.Param != tc.param {
+			t.Errorf("%s: param %q, want %q", tc.target, problem.Param, tc.param)
+		}
+	}
+
+	// A match still answers with the list
+	w := serve("/v1/currency/:currency", GetCountriesByCurrency, httptest.NewRequest(http.MethodGet, "/v1/currency/eur", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("/v1/currency/eur: status %d", w.Code)
+	}
+}
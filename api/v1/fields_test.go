@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// countryFields requests /v1/countries/DE with fields and decodes the result.
+func countryFields(t *testing.T, fields string) map[string]interface{} {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/v1/countries/DE?fields="+url.QueryEscape(fields), nil)
+	w := serve("/v1/countries/:code", GetCountryByCode, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("fields=%s: status %d: %s", fields, w.Code, w.Body)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// decodeJSON decodes a JSON literal of an expected result.
+func decodeJSON(t *testing.T, literal string) map[string]interface{} {
+	t.Helper()
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(literal), &doc); err != nil {
+		t.Fatalf("expected %s: %v", literal, err)
+	}
+	return doc
+}
+
+func TestFieldsNestedProjection(t *testing.T) {
+	loadTestCountries(t)
+
+	for fields, want := range map[string]string{
+		"cca3,capital":                   `{"cca3": "DEU", "capital": ["Berlin"]}`,
+		"name.common,flags.png":          `{"name": {"common": "Germany"}, "flags": {"png": "https://flagcdn.com/w320/de.png"}}`,
+		"currencies.eur.symbol":          `{"currencies": {"EUR": {"symbol": "€"}}}`,
+		"NAME.Common,CCA3":               `{"name": {"common": "Germany"}, "cca3": "DEU"}`,
+		"name.common,name.official":      `{"name": {"common": "Germany", "official": "Federal Republic of Germany"}}`,
+		"translations.fra.common":        `{"translations": {"fra": {"common": "Allemagne"}}}`,
+		"idd.suffixes,unknown,name.none": `{"idd": {"suffixes": ["9"]}}`,
+	} {
+		if got := countryFields(t, fields); !reflect.DeepEqual(got, decodeJSON(t, want)) {
+			t.Errorf("fields=%s:\n got %v\nwant %s", fields, got, want)
+		}
+	}
+}
+
+func TestFieldsWildcards(t *testing.T) {
+	loadTestCountries(t)
+
+	if got, want := countryFields(t, "demonyms.*.f"), `{"demonyms": {"eng": {"f": "German"}, "fra": {"f": "Allemande"}}}`; !reflect.DeepEqual(got, decodeJSON(t, want)) {
+		t.Errorf("fields=demonyms.*.f:\n got %v\nwant %s", got, want)
+	}
+	if got, want := countryFields(t, "*.svg"), `{"flags": {"svg": "https://flagcdn.com/de.svg"}, "coatOfArms": {"svg": "https://mainfacts.com/media/images/coats_of_arms/de.svg"}}`; !reflect.DeepEqual(got, decodeJSON(t, want)) {
+		t.Errorf("fields=*.svg:\n got %v\nwant %s", got, want)
+	}
+
+	// Every translation keeps only its common name
+	translations := countryFields(t, "translations.*.common")["translations"].(map[string]interface{})
+	if len(translations) < 20 {
+		t.Errorf("translations.*.common: %d translations, want every translation", len(translations))
+	}
+	for key, value := range translations {
+		if names := value.(map[string]interface{}); len(names) != 1 || names["common"] == nil {
+			t.Errorf("translations.*.common: %s is %v, want only common", key, names)
+		}
+	}
+}
+
+func TestFieldsExclusion(t *testing.T) {
+	loadTestCountries(t)
+
+	for fields, check := range map[string]func(map[string]interface{}) bool{
+		// Exclusion alone starts from the whole country
+		"-translations": func(doc map[string]interface{}) bool {
+			_, hasTranslations := doc["translations"]
+			return !hasTranslations && doc["cca3"] == "DEU" && doc["population"] != nil
+		},
+		"-translations.*.official,-demonyms.eng": func(doc map[string]interface{}) bool {
+			fra := doc["translations"].(map[string]interface{})["fra"].(map[string]interface{})
+			demonyms := doc["demonyms"].(map[string]interface{})
+			_, hasEng := demonyms["eng"]
+			return len(fra) == 1 && fra["common"] == "Allemagne" && !hasEng && demonyms["fra"] != nil
+		},
+	} {
+		if doc := countryFields(t, fields); !check(doc) {
+			t.Errorf("fields=%s: unexpected result %v", fields, doc)
+		}
+	}
+
+	// Exclusions apply after inclusions, whatever their order
+	for _, fields := range []string{"name,capital,-name.official", "-name.official,name,capital"} {
+		if got, want := countryFields(t, fields), `{"name": {"common": "Germany"}, "capital": ["Berlin"]}`; !reflect.DeepEqual(got, decodeJSON(t, want)) {
+			t.Errorf("fields=%s:\n got %v\nwant %s", fields, got, want)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+// graphql.go contains the GraphQL endpoint over the country dataset: a schema mirroring Country,
+// the countries query taking the filterCountries keys as arguments and the GraphiQL page.
+package v1
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// GraphiQL enables the GraphiQL page on GET /graphql for browsers.
+var GraphiQL = true
+
+// maxGraphQLBody caps the size of a POST /graphql body.
+const maxGraphQLBody = 64 << 10
+
+// graphiQLPage is the query editor served to browsers. It is self-contained, so it works offline
+// and loads no third-party script into this origin.
+//
+//go:embed graphiql.html
+var graphiQLPage []byte
+
+// graphiQLPolicy is the Content-Security-Policy of the GraphiQL page; it may only talk to this
+// origin.
+const graphiQLPolicy = "default-src 'none'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; connect-src 'self'"
+
+// GraphQLRequest is the body of a POST /graphql request.
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// Map-valued Country members are exposed as lists of entries, since GraphQL has no map type.
+type (
+	currencyEntry struct {
+		Code   string `json:"code"`
+		Name   string `json:"name"`
+		Symbol string `json:"symbol"`
+	}
+	languageEntry struct {
+		Code string `json:"code"`
+		Name string `json:"name"`
+	}
+	translationEntry struct {
+		Language string `json:"language"`
+		Official string `json:"official"`
+		Common   string `json:"common"`
+	}
+	giniEntry struct {
+		Year  string  `json:"year"`
+		Value float64 `json:"value"`
+	}
+)
+
+// countryFilterArgs are the countries query arguments; each is a filterCountries key.
+var countryFilterArgs = []struct {
+	name, description string
+}{
+	{"name", "Part of the common or official name"},
+	{"fullName", "Exact common or official name"},
+	{"currency", "Currency code or name"},
+	{"demonym", "English or French demonym"},
+	{"language", "ISO 639 code or name of an official language"},
+	{"capital", "Capital city"},
+	{"region", "Region, e.g. Europe"},
+	{"subregion", "Subregion, e.g. Northern Europe"},
+	{"group", "Group id, e.g. EU"},
+	{"continent", "Continent, e.g. South America"},
+	{"translation", "Part of a translated name"},
+}
+
+// graphQLSchema is built once; resolvers read the current Countries.
+var graphQLSchema = mustBuildGraphQLSchema()
+
+// mustBuildGraphQLSchema builds the schema, panicking on programming errors in its definition.
+func mustBuildGraphQLSchema() graphql.Schema {
+	schema, err := buildGraphQLSchema()
+	if err != nil {
+		panic(fmt.Sprintf("graphql schema: %v", err))
+	}
+	return schema
+}
+
+// nonNull wraps t as non-null.
+func nonNull(t graphql.Type) graphql.Type {
+	return graphql.NewNonNull(t)
+}
+
+// listOf returns the non-null list type of non-null t.
+func listOf(t graphql.Type) graphql.Type {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+// object builds an object type whose fields resolve to the struct members of the same JSON name.
+func object(name string, fields map[string]graphql.Type) *graphql.Object {
+	out := graphql.Fields{}
+	for field, t := range fields {
+		out[field] = &graphql.Field{Type: t}
+	}
+	return graphql.NewObject(graphql.ObjectConfig{Name: name, Fields: out})
+}
+
+// buildGraphQLSchema defines the Country type and the countries and country queries.
+func buildGraphQLSchema() (graphql.Schema, error) {
+	str := nonNull(graphql.String)
+	strs := listOf(graphql.String)
+	floats := listOf(graphql.Float)
+
+	nameType := object("Name", map[string]graphql.Type{"common": str, "official": str})
+	currencyType := object("Currency", map[string]graphql.Type{"code": str, "name": str, "symbol": str})
+	iddType := object("IDD", map[string]graphql.Type{"root": str, "suffixes": strs})
+	mapsType := object("Maps", map[string]graphql.Type{"googleMaps": str, "openStreetMaps": str})
+	carType := object("Car", map[string]graphql.Type{"signs": strs, "side": str})
+	flagsType := object("Flags", map[string]graphql.Type{"svg": str, "png": str, "alt": graphql.String})
+	coatOfArmsType := object("CoatOfArms", map[string]graphql.Type{"svg": str, "png": str})
+	capitalInfoType := object("CapitalInfo", map[string]graphql.Type{"latlng": floats})
+	postalCodeType := object("PostalCode", map[string]graphql.Type{"format": str, "regex": str})
+	demonymType := object("Demonym", map[string]graphql.Type{"f": str, "m": str})
+	demonymsType := object("Demonyms", map[string]graphql.Type{"eng": nonNull(demonymType), "fra": demonymType})
+	languageType := object("Language", map[string]graphql.Type{"code": str, "name": str})
+	translationType := object("Translation", map[string]graphql.Type{"language": str, "official": str, "common": str})
+	giniType := object("Gini", map[string]graphql.Type{"year": str, "value": nonNull(graphql.Float)})
+
+	countryType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Country",
+		Description: "A country, with the members of the REST Country object",
+		Fields: graphql.Fields{
+			"name":         {Type: nonNull(nameType)},
+			"tld":          {Type: strs},
+			"cca2":         {Type: str},
+			"ccn3":         {Type: graphql.String},
+			"cca3":         {Type: str},
+			"cioc":         {Type: graphql.String},
+			"fifa":         {Type: graphql.String},
+			"independent":  {Type: nonNull(graphql.Boolean)},
+			"status":       {Type: graphql.String},
+			"unMember":     {Type: nonNull(graphql.Boolean)},
+			"currencies":   {Type: listOf(currencyType), Resolve: resolveCurrencies},
+			"idd":          {Type: nonNull(iddType)},
+			"capital":      {Type: strs},
+			"altSpellings": {Type: strs},
+			"latlng":       {Type: floats},
+			"landlocked":   {Type: nonNull(graphql.Boolean)},
+			"area":         {Type: nonNull(graphql.Float)},
+			"flag":         {Type: graphql.String},
+			"region":       {Type: str},
+			"subregion":    {Type: graphql.String},
+			"maps":         {Type: nonNull(mapsType)},
+			"population":   {Type: nonNull(graphql.Int)},
+			"gini":         {Type: listOf(giniType), Resolve: resolveGini},
+			"car":          {Type: nonNull(carType)},
+			"timezones":    {Type: strs},
+			"continents":   {Type: strs},
+			"flags":        {Type: nonNull(flagsType)},
+			"coatOfArms":   {Type: nonNull(coatOfArmsType)},
+			"startOfWeek":  {Type: str},
+			"capitalInfo":  {Type: nonNull(capitalInfoType)},
+			"postalCode":   {Type: nonNull(postalCodeType)},
+			"demonyms":     {Type: nonNull(demonymsType)},
+			"languages":    {Type: listOf(languageType), Resolve: resolveLanguages},
+			"translations": {
+				Type: listOf(translationType),
+				Args: graphql.FieldConfigArgument{
+					"language": {Type: graphql.String, Description: "Only the translation into this language, e.g. deu"},
+				},
+				Resolve: resolveTranslations,
+			},
+		},
+	})
+	countryType.AddFieldConfig("borders", &graphql.Field{
+		Type:        listOf(countryType),
+		Description: "The bordering countries",
+		Resolve:     resolveBorders,
+	})
+
+	countriesArgs := graphql.FieldConfigArgument{
+		"independent": {Type: graphql.Boolean, Description: "Independence status"},
+	}
+	for _, arg := range countryFilterArgs {
+		countriesArgs[arg.name] = &graphql.ArgumentConfig{Type: graphql.String, Description: arg.description}
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"countries": {
+				Type:        listOf(countryType),
+				Description: "Countries matching every given argument, as the REST search routes match them",
+				Args:        countriesArgs,
+				Resolve:     resolveCountries,
+			},
+			"country": {
+				Type:        countryType,
+				Description: "The country with the given CCA2, CCA3, CCN3 or CIOC code, or null",
+				Args: graphql.FieldConfigArgument{
+					"code": {Type: nonNull(graphql.String)},
+				},
+				Resolve: resolveCountry,
+			},
+		},
+	})
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// resolveCountries filters Countries by the query arguments.
+func resolveCountries(p graphql.ResolveParams) (interface{}, error) {
+	filters := make(map[string]string, len(p.Args))
+	for key, value := range p.Args {
+		switch v := value.(type) {
+		case bool:
+			filters[key] = fmt.Sprint(v)
+		case string:
+			filters[key] = v
+		}
+	}
+	return SearchCountries(p.Context, filters)
+}
+
+// resolveCountry finds a country by code as /alpha/{code} does.
+func resolveCountry(p graphql.ResolveParams) (interface{}, error) {
+	code, _ := p.Args["code"].(string)
+	if country, ok := FindCountry(code); ok {
+		return country, nil
+	}
+	return nil, nil
+}
+
+// resolveBorders resolves the CCA3 codes in Borders to countries, skipping unknown codes.
+func resolveBorders(p graphql.ResolveParams) (interface{}, error) {
+	country := p.Source.(Country)
+	borders := make([]Country, 0, len(country.Borders))
+	for _, code := range country.Borders {
+		if neighbor, ok := findCountryByCCA3(code); ok {
+			borders = append(borders, neighbor)
+		}
+	}
+	return borders, nil
+}
+
+// findCountryByCCA3 returns the country with the given ISO 3166-1 alpha-3 code.
+func findCountryByCCA3(cca3 string) (Country, bool) {
+	for _, country := range Countries {
+		if strings.EqualFold(country.CCA3, cca3) {
+			return country, true
+		}
+	}
+	return Country{}, false
+}
+
+// resolveCurrencies lists the currencies by code.
+func resolveCurrencies(p graphql.ResolveParams) (interface{}, error) {
+	currencies := p.Source.(Country).Currencies
+	entries := make([]currencyEntry, 0, len(currencies))
+	for _, code := range sortedKeys(currencies) {
+		entries = append(entries, currencyEntry{Code: code, Name: currencies[code].Name, Symbol: currencies[code].Symbol})
+	}
+	return entries, nil
+}
+
+// resolveLanguages lists the official languages by ISO 639 code.
+func resolveLanguages(p graphql.ResolveParams) (interface{}, error) {
+	languages := p.Source.(Country).Languages
+	entries := make([]languageEntry, 0, len(languages))
+	for _, code := range sortedKeys(languages) {
+		entries = append(entries, languageEntry{Code: code, Name: languages[code]})
+	}
+	return entries, nil
+}
+
+// resolveTranslations lists the translations by language, optionally only one of them.
+func resolveTranslations(p graphql.ResolveParams) (interface{}, error) {
+	translations := p.Source.(Country).Translations
+	only, _ := p.Args["language"].(string)
+	entries := make([]translationEntry, 0, len(translations))
+	for _, key := range sortedKeys(translations) {
+		if only != "" && !strings.EqualFold(key, only) {
+			continue
+		}
+		t := translations[key]
+		entries = append(entries, translationEntry{Language: key, Official: t.Official, Common: t.Common})
+	}
+	return entries, nil
+}
+
+// resolveGini lists the Gini coefficients by year.
+func resolveGini(p graphql.ResolveParams) (interface{}, error) {
+	gini := p.Source.(Country).Gini
+	entries := make([]giniEntry, 0, len(gini))
+	for _, year := range sortedKeys(gini) {
+		entries = append(entries, giniEntry{Year: year, Value: gini[year]})
+	}
+	return entries, nil
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GraphQL handles GET and POST requests to /graphql. POST takes a JSON GraphQLRequest or an
+// application/graphql query; GET takes the query, variables and operationName parameters.
+// Browsers requesting the endpoint without a query get GraphiQL when enabled. Query errors are
+// reported in the errors member of a 200 response, as GraphQL clients expect.
+func GraphQL(c *gin.Context) {
+	var req GraphQLRequest
+	switch c.Request.Method {
+	case http.MethodGet:
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if req.Query == "" && GraphiQL && strings.Contains(c.GetHeader("Accept"), "text/html") {
+			c.Header("Content-Security-Policy", graphiQLPolicy)
+			c.Data(http.StatusOK, "text/html; charset=utf-8", graphiQLPage)
+			return
+		}
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "variables", "variables must be a JSON object")
+				return
+			}
+		}
+	default:
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxGraphQLBody)
+		var err error
+		if strings.HasPrefix(c.ContentType(), "application/graphql") {
+			var body []byte
+			if body, err = io.ReadAll(c.Request.Body); err == nil {
+				req.Query = string(body)
+			}
+		} else {
+			err = json.NewDecoder(c.Request.Body).Decode(&req)
+		}
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			respondProblem(c, http.StatusRequestEntityTooLarge, CodeBodyTooLarge, "body",
+				fmt.Sprintf("body exceeds %d bytes", maxGraphQLBody))
+			return
+		case err != nil:
+			respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "body", "body must be a JSON object with a query member")
+			return
+		}
+	}
+	if req.Query == "" {
+		respondProblem(c, http.StatusBadRequest, CodeMissingParameter, "query", "query is required")
+		return
+	}
+
+	// Parse errors are left to graphql.Do, which reports them like any other query error
+	if doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query)})}); err == nil {
+		if err := checkQueryCost(graphQLSchema, doc, req.OperationName); err != nil {
+			c.JSON(http.StatusOK, &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(err.Error())}})
+			return
+		}
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         graphQLSchema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        c.Request.Context(),
+	})
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// graphQLRequest posts query to the GraphQL handler and returns the recorded response.
+func graphQLRequest(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/graphql", GraphQL)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// graphQLErrors runs query and returns the messages of the errors member.
+func graphQLErrors(t *testing.T, query string) []string {
+	t.Helper()
+	body, _ := json.Marshal(GraphQLRequest{Query: query})
+	w := graphQLRequest(t, string(body))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var result struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	messages := make([]string, len(result.Errors))
+	for i, e := range result.Errors {
+		messages[i] = e.Message
+	}
+	return messages
+}
+
+// nestBorders returns a country query selecting borders depth times.
+func nestBorders(depth int) string {
+	return `{ country(code: "DE") { ` + strings.Repeat("borders { ", depth) + "cca3" +
+		strings.Repeat(" }", depth) + " } }"
+}
+
+func TestGraphQLRejectsDeepQueries(t *testing.T) {
+	loadTestCountries(t)
+
+	start := time.Now()
+	errs := graphQLErrors(t, nestBorders(8))
+	if len(errs) != 1 || !strings.Contains(errs[0], "depth") {
+		t.Fatalf("8 nested borders: errors %q, want the depth limit", errs)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("rejecting took %v; the query must not run", elapsed)
+	}
+
+	// Fragments count towards the depth where they are spread
+	errs = graphQLErrors(t, `{ country(code: "DE") { ...b } }
+fragment b on Country { borders { borders { borders { borders { borders { borders { cca3 } } } } } } }`)
+	if len(errs) != 1 || !strings.Contains(errs[0], "depth") {
+		t.Errorf("nested borders in a fragment: errors %q, want the depth limit", errs)
+	}
+}
+
+func TestGraphQLRejectsComplexQueries(t *testing.T) {
+	loadTestCountries(t)
+
+	errs := graphQLErrors(t, `{ countries { borders { borders { cca3 } } } }`)
+	if len(errs) != 1 || !strings.Contains(errs[0], "complexity") {
+		t.Errorf("borders of borders of every country: errors %q, want the complexity limit", errs)
+	}
+}
+
+func TestGraphQLAcceptsOrdinaryQueries(t *testing.T) {
+	loadTestCountries(t)
+
+	for _, query := range []string{
+		nestBorders(3),
+		`{ countries(region: "Europe") { cca3 name { common } currencies { code } borders { cca3 } } }`,
+		`{ __schema { types { name fields { name type { name ofType { name ofType { name ofType { name } } } } } } } }`,
+		// The documentation query of the embedded GraphiQL page
+		`{ __schema { queryType { name } types { name kind description fields { name description type { ` + typeRef +
+			` } args { name type { ` + typeRef + ` } } } } } }`,
+		`{ __type(name: "Country") { name fields { name type { ` + typeRef + ` } } } countries { __typename cca3 } }`,
+	} {
+		if errs := graphQLErrors(t, query); len(errs) != 0 {
+			t.Errorf("%s: unexpected errors %q", query, errs)
+		}
+	}
+}
+
+// typeRef selects a type reference three wrappers deep, as GraphiQL does.
+const typeRef = "kind name ofType { kind name ofType { kind name ofType { kind name } } }"
+
+func TestGraphQLLimitsIntrospection(t *testing.T) {
+	loadTestCountries(t)
+
+	// Aliased copies of a large introspection selection must not multiply the work
+	var query strings.Builder
+	query.WriteString("{")
+	for i := 0; i < 676; i++ {
+		fmt.Fprintf(&query, " s%d: __schema { types { fields { name args { name } type { name fields { name } } } } }", i)
+	}
+	query.WriteString(" }")
+
+	start := time.Now()
+	errs := graphQLErrors(t, query.String())
+	if len(errs) != 1 || !strings.Contains(errs[0], "complexity") {
+		t.Fatalf("aliased introspection: errors %q, want the complexity limit", errs)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("rejecting took %v; the query must not run", elapsed)
+	}
+
+	// A handful of copies stays well within the limits
+	errs = graphQLErrors(t, `{ a: __schema { types { name } } b: __schema { types { name } } }`)
+	if len(errs) != 0 {
+		t.Errorf("two aliased introspection queries: unexpected errors %q", errs)
+	}
+
+	// Introspection nesting is capped too
+	deep := "{ __schema { types { " + strings.Repeat("fields { type { ", 6) + "name" + strings.Repeat(" } }", 6) + " } } }"
+	errs = graphQLErrors(t, deep)
+	if len(errs) != 1 || !strings.Contains(errs[0], "depth") {
+		t.Errorf("deeply nested introspection: errors %q, want the depth limit", errs)
+	}
+}
+
+func TestGraphQLLimitsBodySize(t *testing.T) {
+	body := `{"query": "{ country(code: \"DE\") { cca3 } }", "pad": "` + strings.Repeat("x", maxGraphQLBody) + `"}`
+	if w := graphQLRequest(t, body); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized body: status %d, want 413", w.Code)
+	}
+}
@@ -0,0 +1,152 @@
+// graphqlcost.go contains the depth and complexity limits checked before a GraphQL query runs, so
+// recursive selections such as nested borders cannot make the server resolve millions of nodes.
+package v1
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// GraphQLMaxDepth caps the nesting of field selections in a query; the top-level countries or
+// country field is depth 1.
+var GraphQLMaxDepth = 6
+
+// GraphQLMaxComplexity caps the estimated number of fields a query resolves.
+var GraphQLMaxComplexity = 25000
+
+// graphQLIntrospectionDepth caps the nesting of selections under __schema and __type, which
+// need more levels than data queries for the ofType chains of type references. Their lists are
+// estimated and counted towards GraphQLMaxComplexity like any other.
+const graphQLIntrospectionDepth = 12
+
+// graphQLListEstimate is the assumed length of lists other than countries, such as borders or
+// translations, when estimating complexity.
+const graphQLListEstimate = 10
+
+// queryCost walks the selected operation of a parsed query with the schema types it selects.
+type queryCost struct {
+	fragments map[string]*ast.FragmentDefinition
+	// active holds the fragments being expanded, so cyclic spreads, which validation rejects
+	// anyway, do not recurse forever.
+	active map[string]bool
+}
+
+// checkQueryCost returns an error when the operation of doc named operationName, or every
+// operation when it is empty, nests deeper than GraphQLMaxDepth or exceeds GraphQLMaxComplexity.
+// Introspection fields are counted like other fields; selections under __schema and __type may
+// nest up to graphQLIntrospectionDepth when that is more.
+func checkQueryCost(schema graphql.Schema, doc *ast.Document, operationName string) error {
+	qc := &queryCost{fragments: make(map[string]*ast.FragmentDefinition), active: make(map[string]bool)}
+	var operations []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			qc.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || def.Name != nil && def.Name.Value == operationName {
+				operations = append(operations, def)
+			}
+		}
+	}
+	for _, op := range operations {
+		if op.Operation != ast.OperationTypeQuery {
+			continue
+		}
+		complexity, err := qc.selectionSet(op.SelectionSet, schema.QueryType(), 1, GraphQLMaxDepth)
+		if err != nil {
+			return err
+		}
+		if complexity > GraphQLMaxComplexity {
+			return fmt.Errorf("query complexity exceeds the limit of %d", GraphQLMaxComplexity)
+		}
+	}
+	return nil
+}
+
+// selectionSet returns the estimated complexity of set selected on parent at depth, failing when
+// a field nests deeper than maxDepth. It stops counting once the complexity limit is exceeded.
+func (qc *queryCost) selectionSet(set *ast.SelectionSet, parent *graphql.Object, depth, maxDepth int) (int, error) {
+	if set == nil || parent == nil {
+		return 0, nil
+	}
+	total := 0
+	for _, selection := range set.Selections {
+		var cost int
+		var err error
+		switch selection := selection.(type) {
+		case *ast.Field:
+			cost, err = qc.field(selection, parent, depth, maxDepth)
+		case *ast.InlineFragment:
+			cost, err = qc.selectionSet(selection.SelectionSet, parent, depth, maxDepth)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := qc.fragments[name]
+			if !ok || qc.active[name] {
+				continue
+			}
+			qc.active[name] = true
+			cost, err = qc.selectionSet(fragment.SelectionSet, parent, depth, maxDepth)
+			delete(qc.active, name)
+		}
+		if err != nil {
+			return 0, err
+		}
+		total += cost
+		if total > GraphQLMaxComplexity {
+			return total, nil
+		}
+	}
+	return total, nil
+}
+
+// field returns the complexity of one field: 1, plus its selections times the estimated list
+// length for lists of objects.
+func (qc *queryCost) field(field *ast.Field, parent *graphql.Object, depth, maxDepth int) (int, error) {
+	name := field.Name.Value
+	if depth > maxDepth {
+		return 0, fmt.Errorf("query depth exceeds the limit of %d", maxDepth)
+	}
+	def, ok := parent.Fields()[name]
+	switch name {
+	case "__typename":
+		return 1, nil
+	case "__schema", "__type":
+		def, ok = graphql.SchemaMetaFieldDef, true
+		if name == "__type" {
+			def = graphql.TypeMetaFieldDef
+		}
+		maxDepth = max(maxDepth, graphQLIntrospectionDepth)
+	}
+	if !ok || field.SelectionSet == nil {
+		// Unknown fields are reported by validation
+		return 1, nil
+	}
+
+	factor := 1
+	t := def.Type
+	if nn, ok := t.(*graphql.NonNull); ok {
+		t = nn.OfType
+	}
+	if list, ok := t.(*graphql.List); ok {
+		factor = graphQLListEstimate
+		if name == "countries" && len(Countries) > factor {
+			factor = len(Countries)
+		}
+		t = list.OfType
+		if nn, ok := t.(*graphql.NonNull); ok {
+			t = nn.OfType
+		}
+	}
+	object, _ := t.(*graphql.Object)
+
+	children, err := qc.selectionSet(field.SelectionSet, object, depth+1, maxDepth)
+	if err != nil {
+		return 0, err
+	}
+	if children > GraphQLMaxComplexity {
+		return children, nil
+	}
+	return 1 + factor*children, nil
+}
@@ -0,0 +1,199 @@
+// groups.go contains country groupings (EU, Schengen, ASEAN, OECD, ...) backed by the bundled memberships dataset.
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Group represents an international organisation or area and its member countries (CCA3 codes).
+type Group struct {
+	ID          string   `json:"id" example:"EU"`
+	Name        string   `json:"name" example:"European Union"`
+	Description string   `json:"description,omitempty" example:"Political and economic union of European member states"`
+	Members     []string `json:"members" example:"AUT,BEL,BGR"`
+}
+
+// GroupCatalog is the versioned memberships dataset.
+type GroupCatalog struct {
+	Version string  `json:"version" example:"2026.1"`
+	Updated string  `json:"updated" example:"2026-01-01"`
+	Groups  []Group `json:"groups"`
+}
+
+// Membership represents a group a country belongs to.
+type Membership struct {
+	ID   string `json:"id" example:"EU"`
+	Name string `json:"name" example:"European Union"`
+}
+
+// Groups holds the memberships dataset once loaded.
+var Groups GroupCatalog
+
+// LoadGroupsSafe reads the memberships dataset into the global Groups variable.
+// The data is validated first; on error the previously loaded data stays in place.
+func LoadGroupsSafe(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read groups file: %w", err)
+	}
+	var catalog GroupCatalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return fmt.Errorf("failed to parse groups data: %w", err)
+	}
+	if err := validateGroups(catalog); err != nil {
+		return fmt.Errorf("invalid groups data: %w", err)
+	}
+	Groups = catalog
+	return nil
+}
+
+// validateGroups checks that group IDs are present and unique, ignoring case as findGroup does,
+// and that every member is the CCA3 code of a loaded country, or three uppercase letters when
+// no countries are loaded.
+func validateGroups(catalog GroupCatalog) error {
+	known := make(map[string]bool, len(Countries))
+	for _, country := range Countries {
+		known[country.CCA3] = true
+	}
+
+	seen := make(map[string]bool, len(catalog.Groups))
+	for i, group := range catalog.Groups {
+		if group.ID == "" {
+			return fmt.Errorf("group %d (%s): missing id", i, group.Name)
+		}
+		id := strings.ToUpper(group.ID)
+		if seen[id] {
+			return fmt.Errorf("group %s: duplicate id", group.ID)
+		}
+		seen[id] = true
+
+		for _, member := range group.Members {
+			if len(known) > 0 && !known[member] || len(known) == 0 && !isCCA3(member) {
+				return fmt.Errorf("group %s: member %q is not the cca3 code of a country", group.ID, member)
+			}
+		}
+	}
+	return nil
+}
+
+// isCCA3 reports whether code has the shape of an ISO 3166-1 alpha-3 code.
+func isCCA3(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// findGroup looks up a group by its ID (case-insensitive).
+func findGroup(id string) (Group, bool) {
+	for _, group := range Groups.Groups {
+		if strings.EqualFold(group.ID, id) {
+			return group, true
+		}
+	}
+	return Group{}, false
+}
+
+// isMember reports whether the country is a member of the group.
+func isMember(group Group, country Country) bool {
+	for _, member := range group.Members {
+		if strings.EqualFold(member, country.CCA3) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterByGroup keeps only the countries that are members of the group given by the group query parameter.
+func filterByGroup(c *gin.Context, countries []Country) ([]Country, error) {
+	id := c.Query("group")
+	if id == "" {
+		return countries, nil
+	}
+
+	group, ok := findGroup(id)
+	if !ok {
+		return nil, fmt.Errorf("unknown group: %s", id)
+	}
+
+	var members []Country
+	for _, country := range countries {
+		if isMember(group, country) {
+			members = append(members, country)
+		}
+	}
+	return members, nil
+}
+
+// GetGroups godoc
+// @Summary     Get all country groups
+// @Description Get every country grouping (EU, Schengen, ASEAN, OECD, ...) with its members and the memberships dataset version.
+// @Tags        Groups
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} GroupCatalog
+// @Router      /groups [get]
+func GetGroups(c *gin.Context) {
+	respondData(c, Groups, len(Groups.Groups), len(Groups.Groups))
+}
+
+// GetGroupByID godoc
+// @Summary     Get country group by ID
+// @Description Get a country grouping and its member countries (CCA3 codes).
+// @Tags        Groups
+// @Accept      json
+// @Produce     json
+// @Param       id path string true "Group ID (e.g., EU, SCHENGEN, ASEAN)"
+// @Success     200 {object} Group
+// @Failure     404 {object} Problem
+// @Router      /groups/{id} [get]
+func GetGroupByID(c *gin.Context) {
+	group, ok := findGroup(c.Param("id"))
+	if !ok {
+		respondProblem(c, http.StatusNotFound, CodeGroupNotFound, "id", "Group not found")
+		return
+	}
+	respondData(c, group, 1, len(Groups.Groups))
+}
+
+// GetCountryMemberships godoc
+// @Summary     Get group memberships of a country
+// @Description Get the groups a country (CCA2, CCN3, CCA3 or CIOC code) belongs to.
+// @Tags        Groups
+// @Accept      json
+// @Produce     json
+// @Param       code path string true "Country code (CCA2, CCN3, CCA3, CIOC)"
+// @Success     200 {array}  Membership
+// @Failure     404 {object} Problem
+// @Router      /alpha/{code}/memberships [get]
+func GetCountryMemberships(c *gin.Context) {
+	code := c.Param("code")
+
+	for _, country := range Countries {
+		if strings.EqualFold(country.CCA2, code) ||
+			strings.EqualFold(country.CCA3, code) ||
+			strings.EqualFold(country.CCN3, code) ||
+			strings.EqualFold(country.CIOC, code) {
+			memberships := []Membership{}
+			for _, group := range Groups.Groups {
+				if isMember(group, country) {
+					memberships = append(memberships, Membership{ID: group.ID, Name: group.Name})
+				}
+			}
+			respondData(c, memberships, len(memberships), len(Groups.Groups))
+			return
+		}
+	}
+	respondProblem(c, http.StatusNotFound, CodeCountryNotFound, "code", "Country not found")
+}
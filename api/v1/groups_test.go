@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadGroupsSafeValidates(t *testing.T) {
+	loadTestCountries(t)
+	if err := LoadGroupsSafe("../../data/groups.json"); err != nil {
+		t.Fatal(err)
+	}
+	before := Groups
+
+	dir := t.TempDir()
+	for name, tc := range map[string]struct {
+		content string
+		want    string
+	}{
+		"bad member": {
+			`{"version": "1", "groups": [{"id": "EU", "name": "European Union", "members": ["AUT", "DE"]}]}`,
+			`group EU: member "DE"`,
+		},
+		"unknown member": {
+			`{"version": "1", "groups": [{"id": "EU", "name": "European Union", "members": ["AUT", "ZZZ"]}]}`,
+			`group EU: member "ZZZ"`,
+		},
+		"duplicate id": {
+			`{"version": "1", "groups": [{"id": "EU", "members": ["AUT"]}, {"id": "eu", "members": ["BEL"]}]}`,
+			"group eu: duplicate id",
+		},
+	} {
+		file := filepath.Join(dir, strings.ReplaceAll(name, " ", "_")+".json")
+		if err := os.WriteFile(file, []byte(tc.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		err := LoadGroupsSafe(file)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: error %v, want one containing %q", name, err, tc.want)
+		}
+		if !reflect.DeepEqual(Groups, before) {
+			t.Errorf("%s: failed load replaced the loaded groups", name)
+		}
+	}
+}
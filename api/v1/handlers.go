@@ -2,14 +2,20 @@
 package v1
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
-	"reflect"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // CurrencyInfo defines the structure for each currency in the currencies map.
@@ -111,6 +117,12 @@ type DemonymInfo struct {
 	M string `json:"m" example:"American"`
 }
 
+// Translation is a country's name in one language.
+type Translation struct {
+	Official string `json:"official" example:"Bundesrepublik Deutschland"`
+	Common   string `json:"common" example:"Deutschland"`
+}
+
 // Country is the main data structure.
 type Country struct {
 	Name         Name               `json:"name"`
@@ -147,35 +159,97 @@ type Country struct {
 	PostalCode   PostalCode         `json:"postalCode,omitempty"`
 	Demonyms     Demonyms           `json:"demonyms"`
 
-	Languages    map[string]string `json:"languages,omitempty"`
-	Translations map[string]struct {
-		Official string `json:"official"`
-		Common   string `json:"common"`
-	} `json:"translations,omitempty"`
+	Languages    map[string]string      `json:"languages,omitempty"`
+	Translations map[string]Translation `json:"translations,omitempty"`
 }
 
-// ErrorResponse represents an error response.
+// ErrorResponse is the legacy error shape, returned instead of Problem when LegacyErrors is set.
 type ErrorResponse struct {
 	Message string `json:"message" example:"Bad request"`
 }
 
+// tracer creates the child spans around filtering and field selection.
+var tracer = otel.Tracer("github.com/DoROAD-AI/gcr/api/v1")
+
 // Countries holds the data once loaded.
 var Countries []Country
 
+// CountryRecords holds the records of the countries file as stored, keyed by CCA3, with their
+// members undecoded. They keep what the Country model leaves out, such as name.nativeName.
+var CountryRecords map[string]map[string]json.RawMessage
+
+// DatasetChecksum is the hex SHA-256 of the loaded countries file.
+var DatasetChecksum string
+
+// DatasetLoadedAt is the time the countries file was last loaded successfully.
+var DatasetLoadedAt time.Time
+
 // LoadCountriesSafe reads local JSON data into the global Countries variable.
+// The data is validated first; on error the previously loaded data stays in place.
 func LoadCountriesSafe(filename string) error {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return fmt.Errorf("failed to read countries file: %w", err)
 	}
-	if err := json.Unmarshal(data, &Countries); err != nil {
+	var countries []Country
+	if err := json.Unmarshal(data, &countries); err != nil {
 		return fmt.Errorf("failed to parse countries data: %w", err)
 	}
+	if err := validateCountries(countries); err != nil {
+		return fmt.Errorf("invalid countries data: %w", err)
+	}
+	var stored []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return fmt.Errorf("failed to parse countries data: %w", err)
+	}
+	records := make(map[string]map[string]json.RawMessage, len(stored))
+	for i, record := range stored {
+		records[countries[i].CCA3] = record
+	}
+
+	built, err := buildPayloads(countries)
+	if err != nil {
+		return fmt.Errorf("failed to precompute countries payloads: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	Countries = countries
+	CountryRecords = records
+	payloads = built
+	DatasetChecksum = hex.EncodeToString(sum[:])
+	DatasetLoadedAt = time.Now().UTC()
+	indexTranslations()
+	indexLanguages()
+	indexRegions()
+	return nil
+}
+
+// validateCountries checks that the dataset is non-empty and every country has unique alpha codes.
+func validateCountries(countries []Country) error {
+	if len(countries) == 0 {
+		return fmt.Errorf("no countries found")
+	}
+	seen := make(map[string]bool, len(countries))
+	for i, country := range countries {
+		if len(country.CCA2) != 2 || len(country.CCA3) != 3 {
+			return fmt.Errorf("country %d (%s): invalid cca2 %q or cca3 %q", i, country.Name.Common, country.CCA2, country.CCA3)
+		}
+		if seen[country.CCA3] {
+			return fmt.Errorf("country %d (%s): duplicate cca3 %q", i, country.Name.Common, country.CCA3)
+		}
+		seen[country.CCA3] = true
+	}
 	return nil
 }
 
 // filterCountries applies field-based filtering logic (unchanged).
-func filterCountries(filters map[string]string) []Country {
+func filterCountries(ctx context.Context, filters map[string]string) []Country {
+	_, span := tracer.Start(ctx, "filterCountries")
+	defer span.End()
+	for key, value := range filters {
+		span.SetAttributes(attribute.String("gcr.filter."+key, value))
+	}
+
 	filteredCountries := []Country{}
 
 	for _, country := range Countries {
@@ -266,6 +340,26 @@ func filterCountries(filters map[string]string) []Country {
 					match = false
 				}
 
+			case "group":
+				// group=EU
+				group, ok := findGroup(value)
+				if !ok || !isMember(group, country) {
+					match = false
+				}
+
+			case "continent":
+				// continent=South America
+				found := false
+				for _, cont := range country.Continents {
+					if strings.EqualFold(cont, value) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					match = false
+				}
+
 			case "translation":
 				// translation=Saksamaa
 				found := false
@@ -291,75 +385,89 @@ func filterCountries(filters map[string]string) []Country {
 			filteredCountries = append(filteredCountries, country)
 		}
 	}
+	span.SetAttributes(attribute.Int("gcr.results", len(filteredCountries)))
 	return filteredCountries
 }
 
-// selectFields uses reflection to retrieve nested fields (e.g., "flags.svg").
-func selectFields(country Country, fields []string) map[string]interface{} {
-	result := make(map[string]interface{})
-
-	for _, field := range fields {
-		fieldParts := strings.Split(field, ".")
-		var value interface{} = country
-
-		// Traverse nested fields
-		for _, part := range fieldParts {
-			value = getFieldValue(value, part)
-			if value == nil {
-				break
-			}
-		}
-
-		// If we successfully found a value, store it under the top-level field name
-		if value != nil {
-			result[fieldParts[0]] = value
-		}
+// validateBooleanQuery checks if the query parameter is "true", "false", or empty.
+func validateBooleanQuery(paramValue string) (string, error) {
+	if paramValue == "" {
+		return "", nil
 	}
-
-	return result
+	lowVal := strings.ToLower(paramValue)
+	if lowVal == "true" || lowVal == "false" {
+		return lowVal, nil
+	}
+	return "", fmt.Errorf("invalid boolean value: %s (must be 'true' or 'false')", paramValue)
 }
 
-// getFieldValue dynamically gets the field (case-insensitive) from struct or map.
-func getFieldValue(obj interface{}, fieldName string) interface{} {
-	v := reflect.ValueOf(obj)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
+// respondCountries writes a list of countries, narrowed to the requested group, localized per
+// the negotiated language and reduced to the requested fields.
+func respondCountries(c *gin.Context, countries []Country) {
+	key, tag, err := negotiateLanguage(c)
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "lang", err.Error())
+		return
+	}
+	countries, err = filterByGroup(c, countries)
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "group", err.Error())
+		return
+	}
+	c.Header("Content-Language", tag.String())
+	countries = localizeCountries(countries, key, tag)
+	if countries == nil {
+		// An emptied list is serialized as [] rather than null
+		countries = []Country{}
 	}
 
-	switch v.Kind() {
-	case reflect.Struct:
-		// Case-insensitive match on struct field name
-		f := v.FieldByNameFunc(func(n string) bool {
-			return strings.EqualFold(n, fieldName)
-		})
-		if f.IsValid() && f.CanInterface() {
-			return f.Interface()
-		}
-	case reflect.Map:
-		// Case-insensitive match on map key
-		for _, key := range v.MapKeys() {
-			if key.Kind() == reflect.String && strings.EqualFold(key.String(), fieldName) {
-				value := v.MapIndex(key)
-				if value.IsValid() && value.CanInterface() {
-					return value.Interface()
-				}
-			}
+	fields := c.Query("fields")
+	if fields != "" {
+		_, span := tracer.Start(c.Request.Context(), "selectFields",
+			trace.WithAttributes(attribute.String("gcr.fields", fields), attribute.Int("gcr.countries", len(countries))))
+		projection := projectionFor(fields)
+		result := make([]map[string]interface{}, 0, len(countries))
+		for i := range countries {
+			result = append(result, projection.apply(&countries[i]))
 		}
+		span.End()
+		respondData(c, result, len(result), len(Countries))
+	} else {
+		respondData(c, countries, len(countries), len(Countries))
 	}
+}
 
-	return nil
+// respondMatches writes the countries found by a search on param, or 404 when none matched.
+// Refinements applied afterwards (such as group) may still yield an empty list.
+func respondMatches(c *gin.Context, param, value string, countries []Country) {
+	if len(countries) == 0 {
+		respondProblem(c, http.StatusNotFound, CodeCountryNotFound, param, fmt.Sprintf("No country matches %s '%s'", param, value))
+		return
+	}
+	respondCountries(c, countries)
 }
 
-// validateBooleanQuery checks if the query parameter is "true", "false", or empty.
-func validateBooleanQuery(paramValue string) (string, error) {
-	if paramValue == "" {
-		return "", nil
+// respondCountry writes a single country, localized per the negotiated language and
+// reduced to the requested fields.
+func respondCountry(c *gin.Context, country Country) {
+	key, tag, err := negotiateLanguage(c)
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "lang", err.Error())
+		return
 	}
-	lowVal := strings.ToLower(paramValue)
-	if lowVal == "true" || lowVal == "false" {
-		return lowVal, nil
+	c.Header("Content-Language", tag.String())
+	country = localizeCountry(country, key)
+
+	fields := c.Query("fields")
+	if fields != "" {
+		_, span := tracer.Start(c.Request.Context(), "selectFields",
+			trace.WithAttributes(attribute.String("gcr.fields", fields), attribute.Int("gcr.countries", 1)))
+		result := projectionFor(fields).apply(&country)
+		span.End()
+		respondData(c, result, 1, len(Countries))
+	} else {
+		respondData(c, country, 1, len(Countries))
 	}
-	return "", fmt.Errorf("invalid boolean value: %s (must be 'true' or 'false')", paramValue)
 }
 
 // --------------------------------------------------------------------------
@@ -373,16 +481,22 @@ func validateBooleanQuery(paramValue string) (string, error) {
 // @Accept      json
 // @Produce     json
 // @Param       independent query string false "Filter by independent status (true or false)"
-// @Param       fields      query string false "Comma-separated list of fields to include in the response"
+// @Param       fields      query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang        query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group       query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     400 {object} ErrorResponse
+// @Failure     400 {object} Problem
 // @Router      /countries [get]
 func GetCountries(c *gin.Context) {
+	if writePayload(c, "all") {
+		return
+	}
+
 	filters := make(map[string]string)
 
 	indVal, err := validateBooleanQuery(c.Query("independent"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "independent", err.Error())
 		return
 	}
 	if indVal != "" {
@@ -390,19 +504,9 @@ func GetCountries(c *gin.Context) {
 		filters["independent"] = indVal
 	}
 
-	filteredCountries := filterCountries(filters)
-	fields := c.Query("fields")
+	filteredCountries := filterCountries(c.Request.Context(), filters)
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, country := range filteredCountries {
-			result = append(result, selectFields(country, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondCountries(c, filteredCountries)
 }
 
 // GetCountryByCode godoc
@@ -412,27 +516,23 @@ func GetCountries(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       code   path  string true  "Country code (CCA2 or CCA3)"
-// @Param       fields query string false "Comma-separated list of fields to include in the response"
+// @Param       fields query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang   query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
 // @Success     200 {object} Country
-// @Failure     404 {object} ErrorResponse
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /countries/{code} [get]
 func GetCountryByCode(c *gin.Context) {
 	code := c.Param("code")
-	fields := c.Query("fields")
 
 	for _, country := range Countries {
 		if strings.EqualFold(country.CCA2, code) || strings.EqualFold(country.CCA3, code) {
-			if fields != "" {
-				fieldList := strings.Split(fields, ",")
-				c.JSON(http.StatusOK, selectFields(country, fieldList))
-			} else {
-				c.JSON(http.StatusOK, country)
-			}
+			respondCountry(c, country)
 			return
 		}
 	}
 
-	c.JSON(http.StatusNotFound, ErrorResponse{Message: "Country not found"})
+	respondProblem(c, http.StatusNotFound, CodeCountryNotFound, "code", "Country not found")
 }
 
 // GetCountriesByName godoc
@@ -443,18 +543,20 @@ func GetCountryByCode(c *gin.Context) {
 // @Produce     json
 // @Param       name     path string true  "Country name (common or official)"
 // @Param       fullText query string false "Exact match for full name (true/false)"
-// @Param       fields   query string false "Comma-separated list of fields to include in the response"
+// @Param       fields   query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang     query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group    query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     400 {object} ErrorResponse
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /name/{name} [get]
 func GetCountriesByName(c *gin.Context) {
 	name := c.Param("name")
 	fullTextParam := c.Query("fullText")
-	fields := c.Query("fields")
 
 	boolVal, err := validateBooleanQuery(fullTextParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "fullText", err.Error())
 		return
 	}
 
@@ -465,41 +567,71 @@ func GetCountriesByName(c *gin.Context) {
 		filters["name"] = name
 	}
 
-	filteredCountries := filterCountries(filters)
+	filteredCountries := filterCountries(c.Request.Context(), filters)
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, country := range filteredCountries {
-			result = append(result, selectFields(country, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondMatches(c, "name", name, filteredCountries)
 }
 
+// NotFoundHeader lists the requested codes of /alpha that match no country, comma-separated in
+// request order.
+const NotFoundHeader = "X-Not-Found"
+
 // GetCountriesByCodes godoc
 // @Summary     Get countries by codes
-// @Description Get countries matching a list of codes (CCA2, CCN3, CCA3, or CIOC).
+// @Description Get countries matching a list of codes (CCA2, CCN3, CCA3, or CIOC). Results are in dataset order unless preserveOrder=true, which returns one entry per requested code in request order, duplicates included, with null for codes that match no country or are outside the requested group. Unmatched codes are listed in the X-Not-Found header.
 // @Tags        Countries
 // @Accept      json
 // @Produce     json
-// @Param       codes  query string true  "Comma-separated list of country codes (CCA2, CCN3, CCA3, CIOC)"
-// @Param       fields query string false "Comma-separated list of fields to include in the response"
+// @Param       codes         query string true  "Comma-separated list of country codes (CCA2, CCN3, CCA3, CIOC)"
+// @Param       preserveOrder query bool   false "Return results aligned to the requested codes"
+// @Param       fields        query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang          query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group         query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     400 {object} ErrorResponse
+// @Header      200 {string} X-Not-Found "Requested codes matching no country"
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /alpha [get]
 func GetCountriesByCodes(c *gin.Context) {
 	codes := c.Query("codes")
-	fields := c.Query("fields")
 
 	if codes == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Query parameter 'codes' is required"})
+		respondProblem(c, http.StatusBadRequest, CodeMissingParameter, "codes", "Query parameter 'codes' is required")
+		return
+	}
+	preserveOrder, err := validateBooleanQuery(c.Query("preserveOrder"))
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "preserveOrder", err.Error())
+		return
+	}
+
+	var codeList []string
+	for _, code := range strings.Split(codes, ",") {
+		// Blank entries would match every country without a CIOC code
+		if code = strings.TrimSpace(code); code != "" {
+			codeList = append(codeList, code)
+		}
+	}
+
+	// aligned holds the match of each requested code, nil where there is none
+	aligned := make([]*Country, len(codeList))
+	var notFound []string
+	for i, code := range codeList {
+		if country, ok := FindCountry(code); ok {
+			aligned[i] = &country
+		} else {
+			notFound = append(notFound, code)
+		}
+	}
+	if len(notFound) > 0 {
+		c.Header(NotFoundHeader, strings.Join(notFound, ","))
+	}
+
+	if preserveOrder == "true" {
+		respondAligned(c, codes, aligned)
 		return
 	}
 
-	codeList := strings.Split(codes, ",")
 	var filteredCountries []Country
 
 	for _, country := range Countries {
@@ -514,16 +646,55 @@ func GetCountriesByCodes(c *gin.Context) {
 		}
 	}
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, cty := range filteredCountries {
-			result = append(result, selectFields(cty, fieldList))
+	respondMatches(c, "codes", codes, filteredCountries)
+}
+
+// respondAligned writes one entry per requested code, keeping request order and duplicates.
+// Codes without a match and countries outside the requested group are written as null; the
+// list is localized without re-sorting.
+func respondAligned(c *gin.Context, codes string, aligned []*Country) {
+	matched := false
+	for _, country := range aligned {
+		matched = matched || country != nil
+	}
+	if !matched {
+		respondProblem(c, http.StatusNotFound, CodeCountryNotFound, "codes", fmt.Sprintf("No country matches codes '%s'", codes))
+		return
+	}
+
+	key, tag, err := negotiateLanguage(c)
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "lang", err.Error())
+		return
+	}
+	var group *Group
+	if id := c.Query("group"); id != "" {
+		g, ok := findGroup(id)
+		if !ok {
+			respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "group", fmt.Sprintf("unknown group: %s", id))
+			return
 		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
+		group = &g
+	}
+	c.Header("Content-Language", tag.String())
+
+	var projection *compiledProjection
+	if fields := c.Query("fields"); fields != "" {
+		projection = projectionFor(fields)
 	}
+	result := make([]interface{}, len(aligned))
+	for i, country := range aligned {
+		if country == nil || group != nil && !isMember(*group, *country) {
+			continue
+		}
+		localized := localizeCountry(*country, key)
+		if projection != nil {
+			result[i] = projection.apply(&localized)
+		} else {
+			result[i] = localized
+		}
+	}
+	respondData(c, result, len(result), len(Countries))
 }
 
 // GetCountriesByCurrency godoc
@@ -533,27 +704,20 @@ func GetCountriesByCodes(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       currency path string true  "Currency code or name"
-// @Param       fields   query string false "Comma-separated list of fields to include in the response"
+// @Param       fields   query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang     query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group    query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     404 {object} ErrorResponse
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /currency/{currency} [get]
 func GetCountriesByCurrency(c *gin.Context) {
 	currency := c.Param("currency")
-	fields := c.Query("fields")
 
 	filters := map[string]string{"currency": currency}
-	filteredCountries := filterCountries(filters)
+	filteredCountries := filterCountries(c.Request.Context(), filters)
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, cty := range filteredCountries {
-			result = append(result, selectFields(cty, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondMatches(c, "currency", currency, filteredCountries)
 }
 
 // GetCountriesByDemonym godoc
@@ -563,27 +727,20 @@ func GetCountriesByCurrency(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       demonym path string true  "Demonym"
-// @Param       fields  query string false "Comma-separated list of fields to include in the response"
+// @Param       fields  query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang    query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group   query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     404 {object} ErrorResponse
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /demonym/{demonym} [get]
 func GetCountriesByDemonym(c *gin.Context) {
 	demonym := c.Param("demonym")
-	fields := c.Query("fields")
 
 	filters := map[string]string{"demonym": demonym}
-	filteredCountries := filterCountries(filters)
+	filteredCountries := filterCountries(c.Request.Context(), filters)
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, cty := range filteredCountries {
-			result = append(result, selectFields(cty, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondMatches(c, "demonym", demonym, filteredCountries)
 }
 
 // GetCountriesByLanguage godoc
@@ -593,27 +750,20 @@ func GetCountriesByDemonym(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       language path string true  "Language code or name"
-// @Param       fields   query string false "Comma-separated list of fields to include in the response"
+// @Param       fields   query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang     query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group    query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     404 {object} ErrorResponse
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /lang/{language} [get]
 func GetCountriesByLanguage(c *gin.Context) {
 	language := c.Param("language")
-	fields := c.Query("fields")
 
 	filters := map[string]string{"language": language}
-	filteredCountries := filterCountries(filters)
+	filteredCountries := filterCountries(c.Request.Context(), filters)
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, cty := range filteredCountries {
-			result = append(result, selectFields(cty, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondMatches(c, "language", language, filteredCountries)
 }
 
 // GetCountriesByCapital godoc
@@ -623,27 +773,20 @@ func GetCountriesByLanguage(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       capital path string true  "Capital city name"
-// @Param       fields  query string false "Comma-separated list of fields to include in the response"
+// @Param       fields  query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang    query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group   query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     404 {object} ErrorResponse
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /capital/{capital} [get]
 func GetCountriesByCapital(c *gin.Context) {
 	capital := c.Param("capital")
-	fields := c.Query("fields")
 
 	filters := map[string]string{"capital": capital}
-	filteredCountries := filterCountries(filters)
+	filteredCountries := filterCountries(c.Request.Context(), filters)
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, cty := range filteredCountries {
-			result = append(result, selectFields(cty, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondMatches(c, "capital", capital, filteredCountries)
 }
 
 // GetCountriesByRegion godoc
@@ -653,27 +796,20 @@ func GetCountriesByCapital(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       region path string true  "Region name"
-// @Param       fields query string false "Comma-separated list of fields to include in the response"
+// @Param       fields query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang   query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group  query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     404 {object} ErrorResponse
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /region/{region} [get]
 func GetCountriesByRegion(c *gin.Context) {
 	region := c.Param("region")
-	fields := c.Query("fields")
 
 	filters := map[string]string{"region": region}
-	filteredCountries := filterCountries(filters)
+	filteredCountries := filterCountries(c.Request.Context(), filters)
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, cty := range filteredCountries {
-			result = append(result, selectFields(cty, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondMatches(c, "region", region, filteredCountries)
 }
 
 // GetCountriesBySubregion godoc
@@ -683,27 +819,43 @@ func GetCountriesByRegion(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       subregion path string true  "Subregion name"
-// @Param       fields    query string false "Comma-separated list of fields to include in the response"
+// @Param       fields    query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang      query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group     query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     404 {object} ErrorResponse
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /subregion/{subregion} [get]
 func GetCountriesBySubregion(c *gin.Context) {
 	subregion := c.Param("subregion")
-	fields := c.Query("fields")
 
 	filters := map[string]string{"subregion": subregion}
-	filteredCountries := filterCountries(filters)
+	filteredCountries := filterCountries(c.Request.Context(), filters)
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, cty := range filteredCountries {
-			result = append(result, selectFields(cty, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondMatches(c, "subregion", subregion, filteredCountries)
+}
+
+// GetCountriesByContinent godoc
+// @Summary     Get countries by continent
+// @Description Get countries located (fully or partly) on a continent.
+// @Tags        Countries
+// @Accept      json
+// @Produce     json
+// @Param       continent path string true  "Continent name"
+// @Param       fields    query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang      query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group     query string false "Only include members of this group (e.g., EU, SCHENGEN)"
+// @Success     200 {array}  Country
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
+// @Router      /continent/{continent} [get]
+func GetCountriesByContinent(c *gin.Context) {
+	continent := c.Param("continent")
+
+	filters := map[string]string{"continent": continent}
+	filteredCountries := filterCountries(c.Request.Context(), filters)
+
+	respondMatches(c, "continent", continent, filteredCountries)
 }
 
 // GetCountriesByTranslation godoc
@@ -713,49 +865,39 @@ func GetCountriesBySubregion(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       translation path string true  "Translation"
-// @Param       fields      query string false "Comma-separated list of fields to include in the response"
+// @Param       fields      query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang        query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group       query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     404 {object} ErrorResponse
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /translation/{translation} [get]
 func GetCountriesByTranslation(c *gin.Context) {
 	translation := c.Param("translation")
-	fields := c.Query("fields")
 
 	filters := map[string]string{"translation": translation}
-	filteredCountries := filterCountries(filters)
+	filteredCountries := filterCountries(c.Request.Context(), filters)
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, cty := range filteredCountries {
-			result = append(result, selectFields(cty, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondMatches(c, "translation", translation, filteredCountries)
 }
 
 // GetCountryByAlphaCode handles GET requests to /alpha/{code}.
 func GetCountryByAlphaCode(c *gin.Context) {
 	code := c.Param("code")
-	fields := c.Query("fields")
+	if writePayload(c, strings.ToUpper(code)) {
+		return
+	}
 
 	for _, country := range Countries {
 		if strings.EqualFold(country.CCA2, code) ||
 			strings.EqualFold(country.CCA3, code) ||
 			strings.EqualFold(country.CCN3, code) ||
 			strings.EqualFold(country.CIOC, code) {
-			if fields != "" {
-				fieldList := strings.Split(fields, ",")
-				c.JSON(http.StatusOK, selectFields(country, fieldList))
-			} else {
-				c.JSON(http.StatusOK, country)
-			}
+			respondCountry(c, country)
 			return
 		}
 	}
-	c.JSON(http.StatusNotFound, ErrorResponse{Message: "Country not found"})
+	respondProblem(c, http.StatusNotFound, CodeCountryNotFound, "code", "Country not found")
 }
 
 // GetCountriesByIndependence godoc
@@ -765,9 +907,11 @@ func GetCountryByAlphaCode(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       status query string false "true or false. Defaults to 'true'"
-// @Param       fields query string false "Comma-separated list of fields to include in the response"
+// @Param       fields query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang   query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
+// @Param       group  query string false "Only include members of this group (e.g., EU, SCHENGEN)"
 // @Success     200 {array}  Country
-// @Failure     400 {object} ErrorResponse
+// @Failure     400 {object} Problem
 // @Router      /independent [get]
 func GetCountriesByIndependence(c *gin.Context) {
 	status := c.Query("status")
@@ -777,7 +921,7 @@ func GetCountriesByIndependence(c *gin.Context) {
 
 	statusBool, err := validateBooleanQuery(status)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "status", err.Error())
 		return
 	}
 
@@ -785,19 +929,9 @@ func GetCountriesByIndependence(c *gin.Context) {
 		"independent": statusBool, // "true" or "false"
 	}
 
-	filteredCountries := filterCountries(filters)
-	fields := c.Query("fields")
+	filteredCountries := filterCountries(c.Request.Context(), filters)
 
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, country := range filteredCountries {
-			result = append(result, selectFields(country, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondCountries(c, filteredCountries)
 }
 
 // GetCountryByCCN3 godoc
@@ -807,60 +941,26 @@ func GetCountriesByIndependence(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       code   path  string true  "Numeric code (e.g., 840)"
-// @Param       fields query string false "Comma-separated list of fields to include in the response"
+// @Param       fields query string false "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude"
+// @Param       lang   query string false "Language for localized names (e.g., de, ja); defaults to Accept-Language"
 // @Success     200 {object} Country
-// @Failure     404 {object} ErrorResponse
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
 // @Router      /ccn3/{code} [get]
 func GetCountryByCCN3(c *gin.Context) {
 	code := c.Param("code")
-	fields := c.Query("fields")
 
 	for _, country := range Countries {
 		if strings.EqualFold(country.CCN3, code) {
-			if fields != "" {
-				fieldList := strings.Split(fields, ",")
-				c.JSON(http.StatusOK, selectFields(country, fieldList))
-			} else {
-				c.JSON(http.StatusOK, country)
-			}
+			respondCountry(c, country)
 			return
 		}
 	}
-	c.JSON(http.StatusNotFound, ErrorResponse{Message: "Country not found"})
+	respondProblem(c, http.StatusNotFound, CodeCountryNotFound, "code", "Country not found")
 }
 
 // GetCountriesByCallingCode handles GET requests to /callingcode/{callingcode}.
 func GetCountriesByCallingCode(c *gin.Context) {
 	callingCode := c.Param("callingcode")
-	fields := c.Query("fields")
-	var filteredCountries []Country
-
-	for _, country := range Countries {
-		codeRoot := country.IDD.Root
-		for _, suffix := range country.IDD.Suffixes {
-			fullCode := strings.TrimSpace(codeRoot + suffix)
-			// Remove '+' for comparison
-			fullCode = strings.TrimPrefix(fullCode, "+")
-			if fullCode == callingCode {
-				filteredCountries = append(filteredCountries, country)
-				break
-			}
-		}
-	}
-
-	if len(filteredCountries) == 0 {
-		c.JSON(http.StatusNotFound, ErrorResponse{Message: "Country not found"})
-		return
-	}
-
-	if fields != "" {
-		fieldList := strings.Split(fields, ",")
-		var result []map[string]interface{}
-		for _, country := range filteredCountries {
-			result = append(result, selectFields(country, fieldList))
-		}
-		c.JSON(http.StatusOK, result)
-	} else {
-		c.JSON(http.StatusOK, filteredCountries)
-	}
+	respondMatches(c, "callingcode", callingCode, findCountriesByCallingCode(callingCode))
 }
@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// alphaCodes requests /v1/alpha with query and returns the response.
+func alphaCodes(query string) *httptest.ResponseRecorder {
+	return serve("/v1/alpha", GetCountriesByCodes, httptest.NewRequest(http.MethodGet, "/v1/alpha?"+query, nil))
+}
+
+// alignedCodes decodes a preserveOrder response into the cca3 of each entry, "" for null.
+func alignedCodes(t *testing.T, w *httptest.ResponseRecorder) []string {
+	t.Helper()
+	var entries []*Country
+	if err := json.Unmarshal(w.Body.Bytes(), &entries); err != nil {
+		t.Fatal(err)
+	}
+	codes := make([]string, len(entries))
+	for i, country := range entries {
+		if country != nil {
+			codes[i] = country.CCA3
+		}
+	}
+	return codes
+}
+
+func TestGetCountriesByCodesPreservesOrder(t *testing.T) {
+	loadTestCountries(t)
+	if err := LoadGroupsSafe("../../data/groups.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		query    string
+		want     []string
+		notFound string
+	}{
+		{"codes=FR,de,392", []string{"FRA", "DEU", "JPN"}, ""},
+		{"codes=JP,FR,JP,jpn", []string{"JPN", "FRA", "JPN", "JPN"}, ""},
+		{"codes=FR,ZZZ,DE,QQ", []string{"FRA", "", "DEU", ""}, "ZZZ,QQ"},
+		// Countries outside the group are null like unknown codes, but are not reported missing
+		{"codes=US,DE,ZZZ,FR&group=EU", []string{"", "DEU", "", "FRA"}, "ZZZ"},
+	} {
+		w := alphaCodes(tc.query + "&preserveOrder=true")
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: status %d: %s", tc.query, w.Code, w.Body)
+			continue
+		}
+		if got := alignedCodes(t, w); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: entries %q, want %q", tc.query, got, tc.want)
+		}
+		if got := w.Header().Get(NotFoundHeader); got != tc.notFound {
+			t.Errorf("%s: %s %q, want %q", tc.query, NotFoundHeader, got, tc.notFound)
+		}
+	}
+
+	// Projection applies to every entry, nulls stay null
+	w := alphaCodes("codes=DE,ZZZ&preserveOrder=true&fields=cca3")
+	if w.Body.String() != `[{"cca3":"DEU"},null]` {
+		t.Errorf("fields=cca3: body %s", w.Body)
+	}
+}
+
+func TestGetCountriesByCodesReportsNotFound(t *testing.T) {
+	loadTestCountries(t)
+
+	// Without preserveOrder, matches keep dataset order and unknown codes are only reported
+	w := alphaCodes("codes=FR,ZZZ,DE")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	if got := w.Header().Get(NotFoundHeader); got != "ZZZ" {
+		t.Errorf("%s %q, want ZZZ", NotFoundHeader, got)
+	}
+	var countries []Country
+	if err := json.Unmarshal(w.Body.Bytes(), &countries); err != nil {
+		t.Fatal(err)
+	}
+	if len(countries) != 2 {
+		t.Errorf("%d countries, want 2", len(countries))
+	}
+
+	for _, query := range []string{"codes=ZZZ,QQ", "codes=ZZZ,QQ&preserveOrder=true"} {
+		w := alphaCodes(query)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: status %d, want 404", query, w.Code)
+			continue
+		}
+		var problem Problem
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+			t.Fatal(err)
+		}
+		if problem.Code != CodeCountryNotFound || problem.Param != "codes" {
+			t.Errorf("%s: problem %s on %q, want country_not_found on codes", query, problem.Code, problem.Param)
+		}
+		if got := w.Header().Get(NotFoundHeader); got != "ZZZ,QQ" {
+			t.Errorf("%s: %s %q, want ZZZ,QQ", query, NotFoundHeader, got)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// health.go contains the liveness, readiness and version endpoints used by orchestrators and operators.
+package v1
+
+import (
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DoROAD-AI/gcr/version"
+)
+
+// HealthStatus represents the result of a liveness or readiness probe.
+type HealthStatus struct {
+	Status    string `json:"status" example:"ok"`
+	Countries int    `json:"countries,omitempty" example:"250"`
+}
+
+// DatasetInfo describes the loaded countries dataset.
+type DatasetInfo struct {
+	Checksum string    `json:"checksum" example:"3f5a..."`
+	Records  int       `json:"records" example:"250"`
+	LoadedAt time.Time `json:"loadedAt"`
+}
+
+// VersionInfo reports build metadata and the loaded dataset.
+type VersionInfo struct {
+	Version   string      `json:"version" example:"v1.2.0"`
+	Commit    string      `json:"commit" example:"a1b2c3d"`
+	BuildDate string      `json:"buildDate" example:"2026-01-01T00:00:00Z"`
+	GoVersion string      `json:"goVersion" example:"go1.26.0"`
+	Dataset   DatasetInfo `json:"dataset"`
+}
+
+// Healthz handles GET requests to /healthz. It only reports that the process is serving requests.
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthStatus{Status: "ok"})
+}
+
+// Readyz handles GET requests to /readyz. It reports ready once a validated dataset with at least
+// one country has been loaded, and 503 otherwise.
+func Readyz(c *gin.Context) {
+	if len(Countries) == 0 || DatasetChecksum == "" {
+		respondProblem(c, http.StatusServiceUnavailable, CodeNotReady, "", "Dataset not loaded")
+		return
+	}
+	c.JSON(http.StatusOK, HealthStatus{Status: "ready", Countries: len(Countries)})
+}
+
+// GetVersion handles GET requests to /version.
+func GetVersion(c *gin.Context) {
+	c.JSON(http.StatusOK, VersionInfo{
+		Version:   version.Version,
+		Commit:    version.Commit,
+		BuildDate: version.BuildDate,
+		GoVersion: runtime.Version(),
+		Dataset: DatasetInfo{
+			Checksum: DatasetChecksum,
+			Records:  len(Countries),
+			LoadedAt: DatasetLoadedAt,
+		},
+	})
+}
@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// probe requests path from handler and returns the status code, decoding the body into v.
+func probe(t *testing.T, path string, handler func(*gin.Context), v interface{}) int {
+	t.Helper()
+	w := serve(path, handler, httptest.NewRequest(http.MethodGet, path, nil))
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	return w.Code
+}
+
+func TestHealthzAndReadyzFollowDatasetLoad(t *testing.T) {
+	countries, checksum := Countries, DatasetChecksum
+	t.Cleanup(func() { Countries, DatasetChecksum = countries, checksum })
+	Countries, DatasetChecksum = nil, ""
+
+	var health HealthStatus
+	if code := probe(t, "/healthz", Healthz, &health); code != http.StatusOK || health.Status != "ok" {
+		t.Errorf("/healthz before loading: status %d %+v, want 200 ok", code, health)
+	}
+	var problem Problem
+	if code := probe(t, "/readyz", Readyz, &problem); code != http.StatusServiceUnavailable || problem.Code != CodeNotReady {
+		t.Errorf("/readyz before loading: status %d %s, want 503 not_ready", code, problem.Code)
+	}
+
+	if err := LoadCountriesSafe("../../data/countries.json"); err != nil {
+		t.Fatal(err)
+	}
+	if code := probe(t, "/healthz", Healthz, &health); code != http.StatusOK {
+		t.Errorf("/healthz after loading: status %d, want 200", code)
+	}
+	var ready HealthStatus
+	if code := probe(t, "/readyz", Readyz, &ready); code != http.StatusOK || ready.Status != "ready" || ready.Countries != len(Countries) {
+		t.Errorf("/readyz after loading: status %d %+v, want 200 ready with %d countries", code, ready, len(Countries))
+	}
+}
@@ -0,0 +1,184 @@
+// languages.go contains the language registry derived from Country.Languages and the bundled ISO 639 mapping.
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LanguageCodes holds the ISO 639 equivalents of a language code used in the dataset.
+type LanguageCodes struct {
+	ISO6391  string `json:"iso639_1,omitempty" example:"de"`
+	ISO6392  string `json:"iso639_2,omitempty" example:"deu"`
+	ISO6392B string `json:"iso639_2b,omitempty" example:"ger"`
+	ISO6393  string `json:"iso639_3" example:"deu"`
+}
+
+// Language represents a language spoken officially in one or more countries.
+type Language struct {
+	Code       string   `json:"code" example:"deu"`
+	Name       string   `json:"name" example:"German"`
+	ISO6391    string   `json:"iso639_1,omitempty" example:"de"`
+	ISO6392    string   `json:"iso639_2,omitempty" example:"deu"`
+	ISO6392B   string   `json:"iso639_2b,omitempty" example:"ger"`
+	Countries  []string `json:"countries" example:"AUT,BEL,DEU"`
+	Population int      `json:"population" example:"100000000"`
+}
+
+// LanguageCodeMap maps every language code found in Country.Languages to its ISO 639 equivalents.
+var LanguageCodeMap map[string]LanguageCodes
+
+// LanguagesChecksum is the hex SHA-256 of the loaded languages file.
+var LanguagesChecksum string
+
+// languages is the registry built from Countries and LanguageCodeMap whenever either is loaded.
+var languages []Language
+
+// LoadLanguagesSafe reads the ISO 639 mapping into the global LanguageCodeMap variable.
+// The mapping is validated first; on error the previously loaded mapping stays in place.
+func LoadLanguagesSafe(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read languages file: %w", err)
+	}
+	var codeMap map[string]LanguageCodes
+	if err := json.Unmarshal(data, &codeMap); err != nil {
+		return fmt.Errorf("failed to parse languages data: %w", err)
+	}
+	if err := validateLanguageCodes(codeMap); err != nil {
+		return fmt.Errorf("invalid languages data: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	LanguageCodeMap = codeMap
+	LanguagesChecksum = hex.EncodeToString(sum[:])
+	// Translation keys may use bibliographic codes, which are only resolvable with the mapping
+	indexTranslations()
+	indexLanguages()
+	return nil
+}
+
+// validateLanguageCodes checks that the mapping is non-empty and every entry has an ISO 639-3 code.
+func validateLanguageCodes(codeMap map[string]LanguageCodes) error {
+	if len(codeMap) == 0 {
+		return fmt.Errorf("no languages found")
+	}
+	for code, codes := range codeMap {
+		if codes.ISO6393 == "" {
+			return fmt.Errorf("language %q has no iso639_3 code", code)
+		}
+	}
+	return nil
+}
+
+// indexLanguages rebuilds the language registry from the loaded Countries and LanguageCodeMap.
+func indexLanguages() {
+	languages = buildLanguages()
+}
+
+// buildLanguages aggregates Country.Languages into one entry per ISO 639-3 code, sorted by code.
+func buildLanguages() []Language {
+	index := make(map[string]*Language)
+
+	for _, country := range Countries {
+		for code, name := range country.Languages {
+			codes, ok := LanguageCodeMap[code]
+			if !ok {
+				// Unmapped codes are still listed under their dataset code
+				codes = LanguageCodes{ISO6393: code}
+			}
+
+			lang, exists := index[codes.ISO6393]
+			if !exists {
+				lang = &Language{
+					Code:     codes.ISO6393,
+					Name:     name,
+					ISO6391:  codes.ISO6391,
+					ISO6392:  codes.ISO6392,
+					ISO6392B: codes.ISO6392B,
+				}
+				index[codes.ISO6393] = lang
+			}
+
+			// A country may list the same language under two codes (e.g. "de" and "deu")
+			if !containsString(lang.Countries, country.CCA3) {
+				lang.Countries = append(lang.Countries, country.CCA3)
+				lang.Population += country.Population
+			}
+		}
+	}
+
+	registry := make([]Language, 0, len(index))
+	for _, lang := range index {
+		sort.Strings(lang.Countries)
+		registry = append(registry, *lang)
+	}
+	sort.Slice(registry, func(i, j int) bool {
+		return registry[i].Code < registry[j].Code
+	})
+	return registry
+}
+
+// findLanguage looks up a language by its ISO 639-1, 639-2 (T or B) or 639-3 code.
+func findLanguage(code string) (Language, bool) {
+	for _, lang := range languages {
+		if strings.EqualFold(lang.Code, code) ||
+			strings.EqualFold(lang.ISO6391, code) ||
+			strings.EqualFold(lang.ISO6392, code) ||
+			strings.EqualFold(lang.ISO6392B, code) {
+			return lang, true
+		}
+	}
+	return Language{}, false
+}
+
+// containsString reports whether list contains value.
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLanguages godoc
+// @Summary     Get all languages
+// @Description Get every language found in the dataset with its ISO 639 codes, the countries where it is official and their total population.
+// @Tags        Languages
+// @Accept      json
+// @Produce     json
+// @Success     200 {array} Language
+// @Router      /languages [get]
+func GetLanguages(c *gin.Context) {
+	respondData(c, languages, len(languages), len(languages))
+}
+
+// GetLanguageByCode godoc
+// @Summary     Get language by code
+// @Description Get a language by its ISO 639-1, ISO 639-2 (terminologic or bibliographic) or ISO 639-3 code.
+// @Tags        Languages
+// @Accept      json
+// @Produce     json
+// @Param       code path string true "Language code (e.g., de, deu, ger)"
+// @Success     200 {object} Language
+// @Failure     404 {object} Problem
+// @Router      /languages/{code} [get]
+func GetLanguageByCode(c *gin.Context) {
+	code := c.Param("code")
+
+	lang, ok := findLanguage(code)
+	if !ok {
+		respondProblem(c, http.StatusNotFound, CodeLanguageNotFound, "code", "Language not found")
+		return
+	}
+	respondData(c, lang, 1, len(languages))
+}
@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestLoadLanguagesSafeKeepsPreviousMapping(t *testing.T) {
+	loadTestCountries(t)
+	if err := LoadLanguagesSafe("../../data/languages.json"); err != nil {
+		t.Fatal(err)
+	}
+	before, registry, checksum := LanguageCodeMap, languages, LanguagesChecksum
+	if checksum == "" {
+		t.Fatal("loaded languages without a checksum")
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"malformed.json": `{"deu": {"iso639_3": "deu"`,
+		"empty.json":     `{}`,
+		"no_iso3.json":   `{"deu": {"iso639_1": "de"}}`,
+	} {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := LoadLanguagesSafe(file); err == nil {
+			t.Errorf("%s: loaded without an error", name)
+		}
+		if !reflect.DeepEqual(LanguageCodeMap, before) || !reflect.DeepEqual(languages, registry) || LanguagesChecksum != checksum {
+			t.Errorf("%s: failed load replaced the loaded languages", name)
+		}
+	}
+
+	if lang, ok := findLanguage("ger"); !ok || lang.Code != "deu" {
+		t.Errorf("findLanguage(ger) = %+v, %v; want deu", lang, ok)
+	}
+}
+
+func TestGetLanguages(t *testing.T) {
+	loadTestCountries(t)
+
+	w := serve("/v1/languages", GetLanguages, httptest.NewRequest(http.MethodGet, "/v1/languages", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var got []Language
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) == 0 || !sort.SliceIsSorted(got, func(i, j int) bool { return got[i].Code < got[j].Code }) {
+		t.Fatalf("%d languages, want a non-empty list sorted by code", len(got))
+	}
+	seen := make(map[string]bool, len(got))
+	for _, lang := range got {
+		if seen[lang.Code] {
+			t.Errorf("language %s listed twice", lang.Code)
+		}
+		seen[lang.Code] = true
+	}
+}
+
+func TestGetLanguageByCode(t *testing.T) {
+	loadTestCountries(t)
+
+	// Expected aggregate for German, computed independently of buildLanguages
+	var countries []string
+	population := 0
+	for _, country := range Countries {
+		for code := range country.Languages {
+			if LanguageCodeMap[code].ISO6393 == "deu" {
+				countries = append(countries, country.CCA3)
+				population += country.Population
+				break
+			}
+		}
+	}
+	sort.Strings(countries)
+	if len(countries) < 2 {
+		t.Fatalf("German is official in %v, want several countries", countries)
+	}
+
+	for _, code := range []string{"de", "deu", "ger", "DEU"} {
+		target := "/v1/languages/" + code
+		w := serve("/v1/languages/:code", GetLanguageByCode, httptest.NewRequest(http.MethodGet, target, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", target, w.Code, w.Body)
+		}
+		var lang Language
+		if err := json.Unmarshal(w.Body.Bytes(), &lang); err != nil {
+			t.Fatal(err)
+		}
+		if lang.Code != "deu" || lang.ISO6391 != "de" || lang.ISO6392B != "ger" || lang.Name != "German" {
+			t.Errorf("%s: language %s (%s, %s, %s), want deu", target, lang.Code, lang.Name, lang.ISO6391, lang.ISO6392B)
+		}
+		if !reflect.DeepEqual(lang.Countries, countries) || lang.Population != population {
+			t.Errorf("%s: countries %v with population %d, want %v with %d", target, lang.Countries, lang.Population, countries, population)
+		}
+	}
+
+	w := serve("/v1/languages/:code", GetLanguageByCode, httptest.NewRequest(http.MethodGet, "/v1/languages/xx", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("unknown code: status %d, want 404", w.Code)
+	}
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Code != CodeLanguageNotFound || problem.Param != "code" {
+		t.Errorf("unknown code: problem %s on %q, want language_not_found on code", problem.Code, problem.Param)
+	}
+}
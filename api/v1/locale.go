@@ -0,0 +1,201 @@
+// locale.go contains BCP 47 locale resolution against the country dataset.
+package v1
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// LocaleCandidate represents one language tag parsed from the input with its quality weight.
+type LocaleCandidate struct {
+	Tag     string  `json:"tag" example:"pt-BR"`
+	Quality float32 `json:"q" example:"1"`
+}
+
+// LocaleCountry represents the country a locale resolved to.
+type LocaleCountry struct {
+	CCA2          string `json:"cca2" example:"BR"`
+	CCA3          string `json:"cca3" example:"BRA"`
+	Name          Name   `json:"name"`
+	LocalizedName Name   `json:"localizedName"`
+}
+
+// LocaleLanguage represents the display language a locale resolved to.
+type LocaleLanguage struct {
+	Code     string `json:"code" example:"por"`
+	ISO6391  string `json:"iso639_1,omitempty" example:"pt"`
+	Name     string `json:"name" example:"Portuguese"`
+	Official bool   `json:"official" example:"true"`
+}
+
+// LocaleResolution is the result of resolving a BCP 47 locale.
+type LocaleResolution struct {
+	Input      string            `json:"input" example:"pt-BR,pt;q=0.9,en;q=0.8"`
+	Tag        string            `json:"tag" example:"pt-BR"`
+	Country    LocaleCountry     `json:"country"`
+	Language   LocaleLanguage    `json:"language"`
+	Candidates []LocaleCandidate `json:"candidates"`
+}
+
+// findCountryByCCA2 returns the country with the given ISO 3166-1 alpha-2 code.
+func findCountryByCCA2(cca2 string) (Country, bool) {
+	for _, country := range Countries {
+		if strings.EqualFold(country.CCA2, cca2) {
+			return country, true
+		}
+	}
+	return Country{}, false
+}
+
+// translationFor returns the country name translated into the given language base, if available.
+// English is served from Country.Name since the dataset has no "eng" translation.
+func translationFor(country Country, base language.Base) (Name, bool) {
+	iso3 := base.ISO3()
+	if iso3 == "eng" {
+		return country.Name, true
+	}
+
+	keys := []string{iso3}
+	if codes, ok := LanguageCodeMap[iso3]; ok && codes.ISO6392B != "" {
+		// Some translations are keyed by the bibliographic code (e.g. "per" for Persian)
+		keys = append(keys, codes.ISO6392B)
+	}
+	for _, key := range keys {
+		if tr, ok := country.Translations[key]; ok {
+			return Name{Common: tr.Common, Official: tr.Official}, true
+		}
+	}
+	return Name{}, false
+}
+
+// resolveLocale picks the country and language for tags weighted by weights. Tags are tried in
+// descending weight, ties in input order, and the first whose explicit or inferred region is a
+// country wins, so "de,en-US;q=0.1" resolves to Germany. Tags weighted 0 are never used.
+func resolveLocale(tags []language.Tag, weights []float32) (language.Tag, Country, bool) {
+	order := make([]int, len(tags))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return weights[order[i]] > weights[order[j]] })
+
+	for _, i := range order {
+		if weights[i] <= 0 {
+			continue
+		}
+		region, conf := tags[i].Region()
+		if conf == language.No {
+			continue
+		}
+		if country, ok := findCountryByCCA2(region.String()); ok {
+			return tags[i], country, true
+		}
+	}
+	return language.Tag{}, Country{}, false
+}
+
+// localeLanguage describes the language of tag, preferring the name the country itself uses in Country.Languages.
+func localeLanguage(tag language.Tag, country Country) LocaleLanguage {
+	base, _ := tag.Base()
+	iso3 := base.ISO3()
+
+	result := LocaleLanguage{Code: iso3}
+	if codes, ok := LanguageCodeMap[iso3]; ok {
+		result.ISO6391 = codes.ISO6391
+	}
+
+	for code, name := range country.Languages {
+		codes, ok := LanguageCodeMap[code]
+		if strings.EqualFold(code, iso3) || (ok && codes.ISO6393 == iso3) {
+			result.Name = name
+			result.Official = true
+			return result
+		}
+	}
+
+	if lang, ok := findLanguage(iso3); ok {
+		result.Name = lang.Name
+	} else {
+		result.Name = display.English.Languages().Name(base)
+	}
+	return result
+}
+
+// unencodedAccept reports whether rawQuery has an accept pair with a literal ';', which
+// net/url drops from the parsed query instead of passing it on.
+func unencodedAccept(rawQuery string) bool {
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if strings.HasPrefix(pair, "accept=") && strings.Contains(pair, ";") {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveLocale godoc
+// @Summary     Resolve a BCP 47 locale
+// @Description Resolve a BCP 47 tag or Accept-Language list (with quality weights) into the best-matching country and display language, including the country name localized from its translations. Falls back to the request's Accept-Language header when the accept parameter is omitted. Accept-Language lists with quality weights must be percent-encoded in the query, with ';' sent as %3B; an unencoded ';' is rejected with 400 because Go drops the whole pair.
+// @Tags        Locale
+// @Accept      json
+// @Produce     json
+// @Param       accept query string false "BCP 47 tag or Accept-Language list percent-encoded (e.g., pt-BR,pt%3Bq=0.9,en%3Bq=0.8)"
+// @Success     200 {object} LocaleResolution
+// @Failure     400 {object} Problem
+// @Failure     404 {object} Problem
+// @Router      /locale/resolve [get]
+func ResolveLocale(c *gin.Context) {
+	if unencodedAccept(c.Request.URL.RawQuery) {
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "accept",
+			"Query parameter 'accept' contains an unencoded ';'; percent-encode it as %3B (e.g., pt-BR,pt%3Bq=0.9)")
+		return
+	}
+
+	input := c.Query("accept")
+	if input == "" {
+		input = c.GetHeader("Accept-Language")
+	}
+	if input == "" {
+		respondProblem(c, http.StatusBadRequest, CodeMissingParameter, "accept", "Query parameter 'accept' or the Accept-Language header is required")
+		return
+	}
+
+	tags, weights, err := language.ParseAcceptLanguage(input)
+	if err != nil || len(tags) == 0 {
+		respondProblem(c, http.StatusBadRequest, CodeInvalidParameter, "accept", "Invalid locale: "+input)
+		return
+	}
+
+	tag, country, ok := resolveLocale(tags, weights)
+	if !ok {
+		respondProblem(c, http.StatusNotFound, CodeLocaleNotMatched, "accept", "No country matches the given locale")
+		return
+	}
+
+	base, _ := tag.Base()
+	localized, ok := translationFor(country, base)
+	if !ok {
+		localized = country.Name
+	}
+
+	candidates := make([]LocaleCandidate, 0, len(tags))
+	for i, t := range tags {
+		candidates = append(candidates, LocaleCandidate{Tag: t.String(), Quality: weights[i]})
+	}
+
+	respondData(c, LocaleResolution{
+		Input: input,
+		Tag:   tag.String(),
+		Country: LocaleCountry{
+			CCA2:          country.CCA2,
+			CCA3:          country.CCA3,
+			Name:          country.Name,
+			LocalizedName: localized,
+		},
+		Language:   localeLanguage(tag, country),
+		Candidates: candidates,
+	}, 1, len(Countries))
+}
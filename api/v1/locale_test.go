@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestResolveLocaleFollowsQuality(t *testing.T) {
+	loadTestCountries(t)
+
+	for _, tc := range []struct {
+		input string
+		tag   string
+		cca2  string
+	}{
+		// A preferred tag without a region beats a weaker one with a region
+		{"de,en-US;q=0.1", "de", "DE"},
+		{"en-US;q=0.1,de", "de", "DE"},
+		{"pt-BR,pt;q=0.9,en;q=0.8", "pt-BR", "BR"},
+		{"fr-CA;q=0.5,es-MX;q=0.8", "es-MX", "MX"},
+		// Tags weighted 0 are refused
+		{"de;q=0,fr-CH;q=0.2", "fr-CH", "CH"},
+	} {
+		tags, weights, err := language.ParseAcceptLanguage(tc.input)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.input, err)
+		}
+		tag, country, ok := resolveLocale(tags, weights)
+		if !ok || tag.String() != tc.tag || country.CCA2 != tc.cca2 {
+			t.Errorf("%s: resolved to %s/%s (ok %v), want %s/%s", tc.input, tag, country.CCA2, ok, tc.tag, tc.cca2)
+		}
+	}
+}
+
+func TestResolveLocaleRejectsUnencodedSemicolon(t *testing.T) {
+	loadTestCountries(t)
+
+	for _, tc := range []struct {
+		target string
+		status int
+		code   ErrorCode
+	}{
+		// net/url drops the whole pair, which would otherwise surface as missing_parameter
+		{"/v1/locale/resolve?accept=pt-BR,pt;q=0.9,en;q=0.8", http.StatusBadRequest, CodeInvalidParameter},
+		{"/v1/locale/resolve?fields=tag&accept=pt;q=0.9", http.StatusBadRequest, CodeInvalidParameter},
+		{"/v1/locale/resolve?accept=pt-BR,pt%3Bq=0.9,en%3Bq=0.8", http.StatusOK, ""},
+		{"/v1/locale/resolve", http.StatusBadRequest, CodeMissingParameter},
+	} {
+		w := serve("/v1/locale/resolve", ResolveLocale, httptest.NewRequest(http.MethodGet, tc.target, nil))
+		if w.Code != tc.status {
+			t.Errorf("%s: status %d, want %d: %s", tc.target, w.Code, tc.status, w.Body)
+			continue
+		}
+		if tc.status == http.StatusOK {
+			var resolution LocaleResolution
+			if err := json.Unmarshal(w.Body.Bytes(), &resolution); err != nil {
+				t.Fatal(err)
+			}
+			if resolution.Tag != "pt-BR" || resolution.Country.CCA2 != "BR" {
+				t.Errorf("%s: resolved to %s/%s, want pt-BR/BR", tc.target, resolution.Tag, resolution.Country.CCA2)
+			}
+			continue
+		}
+		var problem Problem
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+			t.Fatal(err)
+		}
+		if problem.Code != tc.code || problem.Param != "accept" {
+			t.Errorf("%s: problem %s on %q, want %s on accept", tc.target, problem.Code, problem.Param, tc.code)
+		}
+		if tc.code == CodeInvalidParameter && !strings.Contains(problem.Detail, "%3B") {
+			t.Errorf("%s: detail %q does not explain the encoding", tc.target, problem.Detail)
+		}
+	}
+}
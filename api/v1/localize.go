@@ -0,0 +1,137 @@
+// localize.go contains language negotiation and localization of country names from Country.Translations.
+package v1
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// translationKeys lists the Country.Translations keys in the order of translationTags.
+// Index 0 is English, served from Country.Name, and has an empty key.
+var translationKeys []string
+
+// translationTags holds the BCP 47 tag of each entry in translationKeys.
+var translationTags []language.Tag
+
+// translationMatcher matches requested languages against translationTags.
+var translationMatcher language.Matcher
+
+// indexTranslations rebuilds the supported translation languages from the loaded Countries.
+func indexTranslations() {
+	keys := []string{""}
+	tags := []language.Tag{language.English}
+	seen := make(map[string]bool)
+
+	for _, country := range Countries {
+		for key := range country.Translations {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			tag, ok := translationTag(key)
+			if !ok {
+				continue
+			}
+			keys = append(keys, key)
+			tags = append(tags, tag)
+		}
+	}
+
+	translationKeys = keys
+	translationTags = tags
+	translationMatcher = language.NewMatcher(tags)
+}
+
+// translationTag converts a Country.Translations key (ISO 639-2/T or /B) to a BCP 47 tag.
+func translationTag(key string) (language.Tag, bool) {
+	// Bibliographic codes such as "per" are not understood by x/text; use their ISO 639-3 form
+	for _, codes := range LanguageCodeMap {
+		if codes.ISO6392B == key {
+			key = codes.ISO6393
+			break
+		}
+	}
+
+	base, err := language.ParseBase(key)
+	if err != nil {
+		return language.Tag{}, false
+	}
+	tag, err := language.Compose(base)
+	if err != nil {
+		return language.Tag{}, false
+	}
+	return tag, true
+}
+
+// negotiateLanguage picks the response language from the lang query parameter, falling back to Accept-Language.
+// It returns the Country.Translations key to use ("" for English) and the tag to report in Content-Language.
+// An explicit but invalid or unsupported lang is an error; an unusable Accept-Language falls back to English.
+func negotiateLanguage(c *gin.Context) (string, language.Tag, error) {
+	if translationMatcher == nil {
+		return "", language.English, nil
+	}
+
+	if lang := c.Query("lang"); lang != "" {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return "", language.English, fmt.Errorf("invalid lang value: %s", lang)
+		}
+		_, idx, conf := translationMatcher.Match(tag)
+		if conf == language.No || (idx == 0 && !sameBase(tag, language.English)) {
+			return "", language.English, fmt.Errorf("unsupported lang value: %s", lang)
+		}
+		return translationKeys[idx], translationTags[idx], nil
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return "", language.English, nil
+	}
+	_, idx, conf := translationMatcher.Match(tags...)
+	if conf == language.No {
+		return "", language.English, nil
+	}
+	return translationKeys[idx], translationTags[idx], nil
+}
+
+// sameBase reports whether two tags share the same base language.
+func sameBase(a, b language.Tag) bool {
+	ba, _ := a.Base()
+	bb, _ := b.Base()
+	return ba == bb
+}
+
+// localizeCountry substitutes the country's name with its translation under key, if present.
+func localizeCountry(country Country, key string) Country {
+	if key == "" {
+		return country
+	}
+	if tr, ok := country.Translations[key]; ok {
+		country.Name = Name{Common: tr.Common, Official: tr.Official}
+	}
+	return country
+}
+
+// localizeCountries localizes every country and sorts the list by localized common name using
+// the collation rules of tag. English (key "") keeps the dataset order.
+func localizeCountries(countries []Country, key string, tag language.Tag) []Country {
+	if key == "" {
+		return countries
+	}
+
+	localized := make([]Country, len(countries))
+	for i, country := range countries {
+		localized[i] = localizeCountry(country, key)
+	}
+
+	collator := collate.New(tag)
+	sort.SliceStable(localized, func(i, j int) bool {
+		return collator.CompareString(localized[i].Name.Common, localized[j].Name.Common) < 0
+	})
+	return localized
+}
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// serve routes req to handler registered under path and returns the recorded response.
+func serve(path string, handler gin.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(req.Method, path, handler)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+	loadTestCountries(t)
+
+	for _, tc := range []struct {
+		target, accept string
+		language, name string
+	}{
+		{"/v1/countries/DE", "", "en", "Germany"},
+		{"/v1/countries/DE", "de-AT,de;q=0.9", "de", "Deutschland"},
+		{"/v1/countries/DE", "ja-JP,en;q=0.5", "ja", "ドイツ"},
+		// lang wins over Accept-Language
+		{"/v1/countries/DE?lang=fr", "de", "fr", "Allemagne"},
+		{"/v1/countries/DE?lang=en", "de", "en", "Germany"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		if tc.accept != "" {
+			req.Header.Set("Accept-Language", tc.accept)
+		}
+		w := serve("/v1/countries/:code", GetCountryByCode, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s (Accept-Language %q): status %d: %s", tc.target, tc.accept, w.Code, w.Body)
+		}
+		if got := w.Header().Get("Content-Language"); got != tc.language {
+			t.Errorf("%s (Accept-Language %q): Content-Language %q, want %q", tc.target, tc.accept, got, tc.language)
+		}
+		var country Country
+		if err := json.Unmarshal(w.Body.Bytes(), &country); err != nil {
+			t.Fatal(err)
+		}
+		if country.Name.Common != tc.name {
+			t.Errorf("%s (Accept-Language %q): name %q, want %q", tc.target, tc.accept, country.Name.Common, tc.name)
+		}
+	}
+}
+
+func TestNegotiateLanguageRejectsUnsupportedLang(t *testing.T) {
+	loadTestCountries(t)
+
+	for _, lang := range []string{"tlh", "not a tag"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/countries/DE?lang="+url.QueryEscape(lang), nil)
+		req.Header.Set("Accept-Language", "de")
+		w := serve("/v1/countries/:code", GetCountryByCode, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("lang=%s: status %d, want 400", lang, w.Code)
+			continue
+		}
+		var problem Problem
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+			t.Fatal(err)
+		}
+		if problem.Code != CodeInvalidParameter || problem.Param != "lang" {
+			t.Errorf("lang=%s: problem %s on %q, want invalid_parameter on lang", lang, problem.Code, problem.Param)
+		}
+	}
+}
+
+func TestLocalizeCountriesSortsByCollation(t *testing.T) {
+	loadTestCountries(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/countries?lang=de&fields=name.common", nil)
+	w := serve("/v1/countries", GetCountries, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	if got := w.Header().Get("Content-Language"); got != "de" {
+		t.Errorf("Content-Language %q, want de", got)
+	}
+	var countries []Country
+	if err := json.Unmarshal(w.Body.Bytes(), &countries); err != nil {
+		t.Fatal(err)
+	}
+	if len(countries) != len(Countries) {
+		t.Fatalf("%d countries, want %d", len(countries), len(Countries))
+	}
+
+	collator := collate.New(language.German)
+	position := make(map[string]int, len(countries))
+	for i, country := range countries {
+		position[country.Name.Common] = i
+		if i > 0 && collator.CompareString(countries[i-1].Name.Common, country.Name.Common) > 0 {
+			t.Errorf("%q listed before %q", countries[i-1].Name.Common, country.Name.Common)
+		}
+	}
+	// Byte order would put Österreich after every name starting with A-Z
+	austria, ok := position["Österreich"]
+	if !ok {
+		t.Fatal("Österreich not listed")
+	}
+	if austria > position["Polen"] {
+		t.Errorf("Österreich at %d after Polen at %d", position["Österreich"], position["Polen"])
+	}
+}
@@ -0,0 +1,94 @@
+// payloads.go contains the precomputed, pre-compressed bodies of the most requested responses.
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DoROAD-AI/gcr/compression"
+)
+
+// Payload is a serialized response body together with its compressed variants.
+type Payload struct {
+	Identity []byte
+	Encoded  map[string][]byte
+}
+
+// payloads holds the bodies served without recomputation: "all" for the full list and the
+// upper-cased cca2, cca3, ccn3 and cioc codes for single countries. It is rebuilt whenever the
+// countries file is loaded.
+var payloads map[string]*Payload
+
+// buildPayloads serializes and compresses the parameterless responses for countries. Alpha codes
+// map to the first country carrying them, matching GetCountryByAlphaCode.
+func buildPayloads(countries []Country) (map[string]*Payload, error) {
+	built := make(map[string]*Payload, 4*len(countries)+1)
+
+	all, err := newPayload(countries)
+	if err != nil {
+		return nil, err
+	}
+	built["all"] = all
+
+	for _, country := range countries {
+		payload, err := newPayload(country)
+		if err != nil {
+			return nil, err
+		}
+		for _, code := range []string{country.CCA2, country.CCA3, country.CCN3, country.CIOC} {
+			key := strings.ToUpper(code)
+			if key == "" || built[key] != nil {
+				continue
+			}
+			built[key] = payload
+		}
+	}
+	return built, nil
+}
+
+// newPayload marshals v exactly as c.JSON would and compresses it with every supported coding.
+func newPayload(v interface{}) (*Payload, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	payload := &Payload{Identity: data, Encoded: make(map[string][]byte, len(compression.Supported))}
+	for _, coding := range compression.Supported {
+		encoded, err := compression.Encode(coding, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress payload with %s: %w", coding, err)
+		}
+		payload.Encoded[coding] = encoded
+	}
+	return payload, nil
+}
+
+// writePayload serves the precomputed body for key if the request has no query parameters, does
+// not ask for the envelope and negotiates the default (English) language. It reports whether the
+// response was written; when false the caller builds the response as usual.
+func writePayload(c *gin.Context, key string) bool {
+	if c.Request.URL.RawQuery != "" || AcceptsEnvelope(c.Request) {
+		return false
+	}
+	payload, ok := payloads[key]
+	if !ok {
+		return false
+	}
+	translation, tag, err := negotiateLanguage(c)
+	if err != nil || translation != "" {
+		return false
+	}
+
+	body := payload.Identity
+	if coding := compression.Negotiate(c.GetHeader("Accept-Encoding")); coding != "" {
+		body = payload.Encoded[coding]
+		c.Header("Content-Encoding", coding)
+	}
+	c.Header("Content-Language", tag.String())
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	return true
+}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWritePayloadServesPrecomputedBodies(t *testing.T) {
+	loadTestCountries(t)
+
+	for _, tc := range []struct {
+		path, target, key string
+		handler           gin.HandlerFunc
+	}{
+		{"/v1/all", "/v1/all", "all", GetCountries},
+		{"/v1/alpha/:code", "/v1/alpha/de", "DE", GetCountryByAlphaCode},
+		{"/v1/alpha/:code", "/v1/alpha/276", "276", GetCountryByAlphaCode},
+	} {
+		for _, coding := range []string{"", "gzip", "br", "zstd"} {
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			if coding != "" {
+				req.Header.Set("Accept-Encoding", coding)
+			}
+			w := serve(tc.path, tc.handler, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("%s: status %d", tc.target, w.Code)
+			}
+			want := payloads[tc.key].Identity
+			if coding != "" {
+				want = payloads[tc.key].Encoded[coding]
+			}
+			if got := w.Header().Get("Content-Encoding"); got != coding {
+				t.Errorf("%s with %q: Content-Encoding %q", tc.target, coding, got)
+			}
+			if !bytes.Equal(w.Body.Bytes(), want) {
+				t.Errorf("%s with %q: body is not the precomputed payload", tc.target, coding)
+			}
+		}
+	}
+}
+
+func TestWritePayloadSkipsVariants(t *testing.T) {
+	loadTestCountries(t)
+
+	for _, tc := range []struct {
+		name, target string
+		header       http.Header
+	}{
+		{"query string", "/v1/all?independent=true", nil},
+		{"fields", "/v1/all?fields=cca3", nil},
+		{"lang", "/v1/all?lang=de", nil},
+		{"lang=en", "/v1/all?lang=en", nil},
+		{"Accept-Language", "/v1/all", http.Header{"Accept-Language": {"de"}}},
+		{"envelope parameter", "/v1/all?envelope=true", nil},
+		{"envelope Accept", "/v1/all", http.Header{"Accept": {EnvelopeContentType}}},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		for name, values := range tc.header {
+			req.Header[name] = values
+		}
+		// Without the compression middleware only a precomputed payload is encoded
+		req.Header.Set("Accept-Encoding", "gzip")
+		w := serve("/v1/all", GetCountries, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", tc.name, w.Code, w.Body)
+		}
+		if got := w.Header().Get("Content-Encoding"); got != "" {
+			t.Errorf("%s: served the precomputed payload (Content-Encoding %q)", tc.name, got)
+		}
+	}
+
+	// A plain request with English Accept-Language still gets the payload
+	req := httptest.NewRequest(http.MethodGet, "/v1/all", nil)
+	req.Header.Set("Accept-Language", "en-GB,en;q=0.9")
+	req.Header.Set("Accept-Encoding", "gzip")
+	if w := serve("/v1/all", GetCountries, req); w.Header().Get("Content-Encoding") != "gzip" {
+		t.Error("English Accept-Language: precomputed payload not served")
+	}
+}
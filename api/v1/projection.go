@@ -0,0 +1,335 @@
+// projection.go contains the fields parameter projection: nested inclusion, exclusion and
+// wildcards over the JSON members of a country, compiled once per fields string into accessor
+// closures so no reflection or JSON round trip happens per country.
+package v1
+
+import (
+	"strings"
+	"sync"
+)
+
+//go:generate go run ../../internal/fieldgen -type Country -output fields_gen.go
+
+// fieldSchema describes the JSON members of a struct type, or the values of a map type keyed
+// by strings. Schemas are generated by fieldgen from the struct tags; struct accessors receive
+// a pointer to the struct.
+type fieldSchema struct {
+	// members are the struct members in declaration order, byName indexes them by lower-cased
+	// JSON name.
+	members []*fieldMember
+	byName  map[string]*fieldMember
+
+	// keys and lookup read a map value; elem describes its values and is nil for leaves.
+	keys   func(v interface{}) []string
+	lookup func(v interface{}, key string) (interface{}, bool)
+	elem   *fieldSchema
+}
+
+// fieldMember is one JSON member of a struct.
+type fieldMember struct {
+	name string
+	get  func(v interface{}) interface{}
+	// empty reports whether the value is omitted under omitempty; nil for members always present.
+	empty func(v interface{}) bool
+	// schema describes the value, nil for leaves.
+	schema *fieldSchema
+}
+
+// structSchema builds the schema of a struct from its members.
+func structSchema(members []*fieldMember) *fieldSchema {
+	s := &fieldSchema{members: members, byName: make(map[string]*fieldMember, len(members))}
+	for _, m := range members {
+		s.byName[strings.ToLower(m.name)] = m
+	}
+	return s
+}
+
+// isMap reports whether the schema describes a map.
+func (s *fieldSchema) isMap() bool {
+	return s.keys != nil
+}
+
+// present returns the member's value and whether it appears in the JSON output.
+func (m *fieldMember) present(v interface{}) (interface{}, bool) {
+	value := m.get(v)
+	if m.empty != nil && m.empty(value) {
+		return nil, false
+	}
+	return value, true
+}
+
+// mapKey finds the key of a map value matching key exactly or, failing that, case-insensitively.
+func (s *fieldSchema) mapKey(v interface{}, key string) (string, bool) {
+	if _, ok := s.lookup(v, key); ok {
+		return key, true
+	}
+	for _, k := range s.keys(v) {
+		if strings.EqualFold(k, key) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// expand turns a struct or map value into a document level of its members, keeping member
+// values as they are. Values already expanded are returned unchanged.
+func (s *fieldSchema) expand(v interface{}) map[string]interface{} {
+	if doc, ok := v.(map[string]interface{}); ok {
+		return doc
+	}
+	if s.isMap() {
+		keys := s.keys(v)
+		doc := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			doc[key], _ = s.lookup(v, key)
+		}
+		return doc
+	}
+	doc := make(map[string]interface{}, len(s.members))
+	for _, m := range s.members {
+		if value, ok := m.present(v); ok {
+			doc[m.name] = value
+		}
+	}
+	return doc
+}
+
+// extractor projects a value onto one inclusion path, reporting false when nothing matched.
+type extractor func(v interface{}) (interface{}, bool)
+
+// remover deletes one exclusion path from an expanded document level.
+type remover func(doc map[string]interface{})
+
+// compiledProjection is a fields parameter compiled against the Country schema. Projections
+// are immutable and shared between requests.
+type compiledProjection struct {
+	include []extractor
+	exclude []remover
+}
+
+// compileProjection splits fields entries into inclusion paths and exclusion paths (prefixed
+// with "-") and compiles each against the Country schema. Blank entries are ignored.
+func compileProjection(fields string) *compiledProjection {
+	p := &compiledProjection{}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if excluded, ok := strings.CutPrefix(field, "-"); ok {
+			if excluded != "" {
+				p.exclude = append(p.exclude, compileRemover(countrySchema, strings.Split(excluded, ".")))
+			}
+		} else if field != "" {
+			p.include = append(p.include, compileExtractor(countrySchema, strings.Split(field, ".")))
+		}
+	}
+	return p
+}
+
+// compileExtractor builds the extractor for path below a value described by s. Struct members
+// are resolved here, so only map keys are matched at run time.
+func compileExtractor(s *fieldSchema, path []string) extractor {
+	if len(path) == 0 {
+		return func(v interface{}) (interface{}, bool) { return v, true }
+	}
+	if s == nil {
+		// Leaves have no members to descend into
+		return func(interface{}) (interface{}, bool) { return nil, false }
+	}
+	segment, rest := path[0], path[1:]
+
+	if s.isMap() {
+		next := compileExtractor(s.elem, rest)
+		if segment == "*" {
+			return func(v interface{}) (interface{}, bool) {
+				out := make(map[string]interface{})
+				for _, key := range s.keys(v) {
+					value, _ := s.lookup(v, key)
+					if projected, ok := next(value); ok {
+						out[key] = projected
+					}
+				}
+				return out, len(out) > 0
+			}
+		}
+		return func(v interface{}) (interface{}, bool) {
+			key, ok := s.mapKey(v, segment)
+			if !ok {
+				return nil, false
+			}
+			value, _ := s.lookup(v, key)
+			projected, ok := next(value)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{key: projected}, true
+		}
+	}
+
+	var members []*fieldMember
+	if segment == "*" {
+		members = s.members
+	} else if m, ok := s.byName[strings.ToLower(segment)]; ok {
+		members = []*fieldMember{m}
+	}
+	nexts := make([]extractor, len(members))
+	for i, m := range members {
+		nexts[i] = compileExtractor(m.schema, rest)
+	}
+	return func(v interface{}) (interface{}, bool) {
+		var out map[string]interface{}
+		for i, m := range members {
+			value, ok := m.present(v)
+			if !ok || value == nil && len(rest) > 0 {
+				continue
+			}
+			if projected, ok := nexts[i](value); ok {
+				if out == nil {
+					out = make(map[string]interface{}, len(members))
+				}
+				out[m.name] = projected
+			}
+		}
+		return out, out != nil
+	}
+}
+
+// compileRemover builds the remover for path within a document level described by s. Member
+// values are expanded only when the path descends into them.
+func compileRemover(s *fieldSchema, path []string) remover {
+	if s == nil {
+		return func(map[string]interface{}) {}
+	}
+	segment, rest := path[0], path[1:]
+
+	if s.isMap() {
+		var next remover
+		if len(rest) > 0 {
+			next = compileRemover(s.elem, rest)
+		}
+		removeKey := func(doc map[string]interface{}, key string) {
+			if next == nil {
+				delete(doc, key)
+			} else if value := doc[key]; value != nil && s.elem != nil {
+				child := s.elem.expand(value)
+				doc[key] = child
+				next(child)
+			}
+		}
+		return func(doc map[string]interface{}) {
+			if segment == "*" {
+				for key := range doc {
+					removeKey(doc, key)
+				}
+				return
+			}
+			if _, ok := doc[segment]; ok {
+				removeKey(doc, segment)
+				return
+			}
+			for key := range doc {
+				if strings.EqualFold(key, segment) {
+					removeKey(doc, key)
+					return
+				}
+			}
+		}
+	}
+
+	var members []*fieldMember
+	if segment == "*" {
+		members = s.members
+	} else if m, ok := s.byName[strings.ToLower(segment)]; ok {
+		members = []*fieldMember{m}
+	}
+	nexts := make([]remover, len(members))
+	for i, m := range members {
+		if len(rest) > 0 {
+			nexts[i] = compileRemover(m.schema, rest)
+		}
+	}
+	return func(doc map[string]interface{}) {
+		for i, m := range members {
+			value, ok := doc[m.name]
+			if !ok {
+				continue
+			}
+			if nexts[i] == nil {
+				delete(doc, m.name)
+			} else if value != nil && m.schema != nil {
+				child := m.schema.expand(value)
+				doc[m.name] = child
+				nexts[i](child)
+			}
+		}
+	}
+}
+
+// apply projects a country. With inclusion paths only those are kept, nested under their
+// parents (flags.svg and flags.png give {"flags":{"svg":..,"png":..}}); without them every
+// member is kept. Exclusion paths are removed afterwards, so "-translations" or
+// "name,-name.official" work as expected. Names match the JSON names, case-insensitively.
+func (p *compiledProjection) apply(country *Country) map[string]interface{} {
+	var result map[string]interface{}
+	if len(p.include) == 0 {
+		result = countrySchema.expand(country)
+	} else {
+		result = make(map[string]interface{})
+		for _, extract := range p.include {
+			if projected, ok := extract(country); ok {
+				mergeDocuments(result, projected.(map[string]interface{}))
+			}
+		}
+	}
+	for _, remove := range p.exclude {
+		remove(result)
+	}
+	return result
+}
+
+// mergeDocuments merges src into dst. Projected levels present in both are combined; a whole
+// value wins over a projection of it, since it contains everything the projection selects.
+func mergeDocuments(dst, src map[string]interface{}) {
+	for key, value := range src {
+		existing, found := dst[key]
+		if !found {
+			dst[key] = value
+			continue
+		}
+		existingDoc, existingProjected := existing.(map[string]interface{})
+		incomingDoc, incomingProjected := value.(map[string]interface{})
+		switch {
+		case existingProjected && incomingProjected:
+			mergeDocuments(existingDoc, incomingDoc)
+		case existingProjected:
+			dst[key] = value
+		}
+	}
+}
+
+// projectionCacheSize bounds the number of distinct fields strings kept compiled.
+const projectionCacheSize = 1024
+
+// projectionCache holds compiled projections by fields string. When full it is cleared rather
+// than evicted entry by entry; the working set of real clients is far smaller.
+var projectionCache = struct {
+	sync.RWMutex
+	entries map[string]*compiledProjection
+}{entries: make(map[string]*compiledProjection)}
+
+// projectionFor returns the compiled projection of fields, compiling it on first use.
+func projectionFor(fields string) *compiledProjection {
+	projectionCache.RLock()
+	p, ok := projectionCache.entries[fields]
+	projectionCache.RUnlock()
+	if ok {
+		return p
+	}
+
+	p = compileProjection(fields)
+	projectionCache.Lock()
+	if len(projectionCache.entries) >= projectionCacheSize {
+		projectionCache.entries = make(map[string]*compiledProjection)
+	}
+	projectionCache.entries[fields] = p
+	projectionCache.Unlock()
+	return p
+}
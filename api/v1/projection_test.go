@@ -0,0 +1,255 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// projectionCases are fields parameters covering plain, nested, wildcard, map key and
+// exclusion paths.
+var projectionCases = []string{
+	"name",
+	"cca2,cca3,population",
+	"name.common,flags.png,flags.svg",
+	"name,name.common",
+	"name.common,name",
+	"NAME.Common,Population",
+	"languages,currencies.*.name",
+	"translations.*.common",
+	"translations.fra,translations.deu.official",
+	"demonyms.*.f,demonyms.fra",
+	"*.svg",
+	"*.*",
+	"name.*,idd.root,-name.nativeName",
+	"-translations",
+	"-translations.*.official,-demonyms.eng",
+	"name,capital,-name.official",
+	"gini,gini.2019",
+	"population.value,area",
+	"unknown,cca2",
+	"tld,latlng,borders,postalCode,capitalInfo.latlng",
+}
+
+var loadCountriesOnce sync.Once
+
+// loadTestCountries loads the bundled dataset once per test binary.
+func loadTestCountries(tb testing.TB) []Country {
+	tb.Helper()
+	var err error
+	loadCountriesOnce.Do(func() {
+		err = LoadCountriesSafe("../../data/countries.json")
+	})
+	if err != nil {
+		tb.Fatalf("loading countries: %v", err)
+	}
+	if len(Countries) == 0 {
+		tb.Fatal("no countries loaded")
+	}
+	return Countries
+}
+
+func TestCompiledProjectionMatchesReference(t *testing.T) {
+	countries := loadTestCountries(t)
+	for _, fields := range projectionCases {
+		projection := compileProjection(fields)
+		for i := range countries {
+			want, err := json.Marshal(referenceSelectFields(countries[i], strings.Split(fields, ",")))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(projection.apply(&countries[i]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameJSON(t, got, want) {
+				t.Fatalf("fields=%q country %s:\n got %s\nwant %s", fields, countries[i].CCA3, got, want)
+			}
+		}
+	}
+}
+
+// sameJSON reports whether a and b encode the same value. Whole struct values keep their
+// declaration order in compiled projections, while the reference sorts their keys.
+func sameJSON(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatal(err)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func TestCompiledProjectionDoesNotModifyCountry(t *testing.T) {
+	countries := loadTestCountries(t)
+	before, err := json.Marshal(countries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fields := range projectionCases {
+		projection := compileProjection(fields)
+		for i := range countries {
+			projection.apply(&countries[i])
+		}
+	}
+	after, err := json.Marshal(countries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Fatal("projection modified the dataset")
+	}
+}
+
+func TestProjectionForCachesPerFields(t *testing.T) {
+	if projectionFor("name,cca2") != projectionFor("name,cca2") {
+		t.Fatal("projection compiled twice for the same fields")
+	}
+	if projectionFor("name,cca2") == projectionFor("cca2,name") {
+		t.Fatal("distinct fields share a projection")
+	}
+}
+
+func BenchmarkSelectFields(b *testing.B) {
+	countries := loadTestCountries(b)
+	for _, fields := range []string{"name.common,cca2", "name,flags.png,translations.*.common", "-translations"} {
+		b.Run("document/"+fields, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				fieldList := strings.Split(fields, ",")
+				for i := range countries {
+					referenceSelectFields(countries[i], fieldList)
+				}
+			}
+		})
+		b.Run("compiled/"+fields, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				projection := projectionFor(fields)
+				for i := range countries {
+					projection.apply(&countries[i])
+				}
+			}
+		})
+	}
+}
+
+// referenceSelectFields is the previous implementation, which projects the JSON document of
+// the country. It defines the expected output and the baseline of the benchmarks.
+func referenceSelectFields(country Country, fields []string) map[string]interface{} {
+	var include, exclude [][]string
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if excluded, ok := strings.CutPrefix(field, "-"); ok {
+			if excluded != "" {
+				exclude = append(exclude, strings.Split(excluded, "."))
+			}
+		} else if field != "" {
+			include = append(include, strings.Split(field, "."))
+		}
+	}
+
+	data, err := json.Marshal(country)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return map[string]interface{}{}
+	}
+
+	result := doc
+	if len(include) > 0 {
+		result = make(map[string]interface{})
+		for _, path := range include {
+			if extracted, ok := referenceExtract(doc, path); ok {
+				referenceMerge(result, extracted.(map[string]interface{}))
+			}
+		}
+	}
+	for _, path := range exclude {
+		referenceRemove(result, path)
+	}
+	return result
+}
+
+func referenceMatchKeys(m map[string]interface{}, segment string) []string {
+	if segment == "*" {
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		return keys
+	}
+	if _, ok := m[segment]; ok {
+		return []string{segment}
+	}
+	for key := range m {
+		if strings.EqualFold(key, segment) {
+			return []string{key}
+		}
+	}
+	return nil
+}
+
+func referenceExtract(node interface{}, path []string) (interface{}, bool) {
+	if len(path) == 0 {
+		return node, true
+	}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{})
+		for _, key := range referenceMatchKeys(n, path[0]) {
+			if value, ok := referenceExtract(n[key], path[1:]); ok {
+				out[key] = value
+			}
+		}
+		return out, len(out) > 0
+	case []interface{}:
+		out := make([]interface{}, 0, len(n))
+		for _, elem := range n {
+			if value, ok := referenceExtract(elem, path); ok {
+				out = append(out, value)
+			}
+		}
+		return out, len(out) > 0
+	}
+	return nil, false
+}
+
+func referenceMerge(dst, src map[string]interface{}) {
+	for key, value := range src {
+		existing, ok := dst[key].(map[string]interface{})
+		incoming, isMap := value.(map[string]interface{})
+		if ok && isMap {
+			referenceMerge(existing, incoming)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+func referenceRemove(node interface{}, path []string) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for _, key := range referenceMatchKeys(n, path[0]) {
+			if len(path) == 1 {
+				delete(n, key)
+			} else {
+				referenceRemove(n[key], path[1:])
+			}
+		}
+	case []interface{}:
+		for _, elem := range n {
+			referenceRemove(elem, path)
+		}
+	}
+}
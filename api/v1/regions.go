@@ -0,0 +1,158 @@
+// regions.go contains the region, subregion and continent catalogs derived from the country dataset.
+package v1
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GeoNode represents a region, subregion or continent with aggregate figures over its countries.
+type GeoNode struct {
+	Name         string  `json:"name" example:"Western Europe"`
+	CountryCount int     `json:"countryCount" example:"8"`
+	Population   int     `json:"population" example:"196000000"`
+	Area         float64 `json:"area" example:"1108000"`
+}
+
+// Region represents a region with its subregions.
+type Region struct {
+	GeoNode
+	Subregions []GeoNode `json:"subregions"`
+}
+
+// regions and continents are the catalogs built from Countries whenever it is loaded.
+var (
+	regions    []Region
+	continents []GeoNode
+)
+
+// indexRegions rebuilds the region and continent catalogs from the loaded Countries.
+func indexRegions() {
+	regions = buildRegions()
+	continents = buildContinents()
+}
+
+// add accumulates a country into the node's aggregates.
+func (n *GeoNode) add(country Country) {
+	n.CountryCount++
+	n.Population += country.Population
+	n.Area += country.Area
+}
+
+// sortedNodes returns the nodes of index sorted by name.
+func sortedNodes(index map[string]*GeoNode) []GeoNode {
+	nodes := make([]GeoNode, 0, len(index))
+	for _, node := range index {
+		nodes = append(nodes, *node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes
+}
+
+// buildRegions derives the region/subregion hierarchy from Country.Region and Country.Subregion.
+// Countries without a subregion only count towards their region.
+func buildRegions() []Region {
+	index := make(map[string]*GeoNode)
+	subregions := make(map[string]map[string]*GeoNode)
+
+	for _, country := range Countries {
+		if country.Region == "" {
+			continue
+		}
+		region, ok := index[country.Region]
+		if !ok {
+			region = &GeoNode{Name: country.Region}
+			index[country.Region] = region
+			subregions[country.Region] = make(map[string]*GeoNode)
+		}
+		region.add(country)
+
+		if country.Subregion == "" {
+			continue
+		}
+		sub, ok := subregions[country.Region][country.Subregion]
+		if !ok {
+			sub = &GeoNode{Name: country.Subregion}
+			subregions[country.Region][country.Subregion] = sub
+		}
+		sub.add(country)
+	}
+
+	result := make([]Region, 0, len(index))
+	for _, node := range sortedNodes(index) {
+		result = append(result, Region{
+			GeoNode:    node,
+			Subregions: sortedNodes(subregions[node.Name]),
+		})
+	}
+	return result
+}
+
+// buildContinents derives the continent catalog from Country.Continents.
+// A country spanning several continents counts towards each of them.
+func buildContinents() []GeoNode {
+	index := make(map[string]*GeoNode)
+
+	for _, country := range Countries {
+		for _, name := range country.Continents {
+			continent, ok := index[name]
+			if !ok {
+				continent = &GeoNode{Name: name}
+				index[name] = continent
+			}
+			continent.add(country)
+		}
+	}
+	return sortedNodes(index)
+}
+
+// GetRegions godoc
+// @Summary     Get all regions
+// @Description Get every region with its subregions, country counts and aggregate population and area.
+// @Tags        Regions
+// @Accept      json
+// @Produce     json
+// @Success     200 {array} Region
+// @Router      /regions [get]
+func GetRegions(c *gin.Context) {
+	respondData(c, regions, len(regions), len(regions))
+}
+
+// GetSubregionsByRegion godoc
+// @Summary     Get subregions of a region
+// @Description Get the subregions of a region with country counts and aggregate population and area.
+// @Tags        Regions
+// @Accept      json
+// @Produce     json
+// @Param       region path string true "Region name"
+// @Success     200 {array}  GeoNode
+// @Failure     404 {object} Problem
+// @Router      /regions/{region}/subregions [get]
+func GetSubregionsByRegion(c *gin.Context) {
+	name := c.Param("region")
+
+	for _, region := range regions {
+		if strings.EqualFold(region.Name, name) {
+			respondData(c, region.Subregions, len(region.Subregions), len(region.Subregions))
+			return
+		}
+	}
+	respondProblem(c, http.StatusNotFound, CodeRegionNotFound, "region", "Region not found")
+}
+
+// GetContinents godoc
+// @Summary     Get all continents
+// @Description Get every continent with country counts and aggregate population and area.
+// @Tags        Regions
+// @Accept      json
+// @Produce     json
+// @Success     200 {array} GeoNode
+// @Router      /continents [get]
+func GetContinents(c *gin.Context) {
+	respondData(c, continents, len(continents), len(continents))
+}
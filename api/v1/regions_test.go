@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGetRegions(t *testing.T) {
+	countries := loadTestCountries(t)
+
+	w := serve("/v1/regions", GetRegions, httptest.NewRequest(http.MethodGet, "/v1/regions", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var got []Region
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	// Expected aggregates, computed independently of buildRegions
+	want := make(map[string]GeoNode)
+	wantSub := make(map[string]map[string]GeoNode)
+	for _, country := range countries {
+		region := want[country.Region]
+		region.Name = country.Region
+		region.add(country)
+		want[country.Region] = region
+		if country.Subregion == "" {
+			continue
+		}
+		if wantSub[country.Region] == nil {
+			wantSub[country.Region] = make(map[string]GeoNode)
+		}
+		sub := wantSub[country.Region][country.Subregion]
+		sub.Name = country.Subregion
+		sub.add(country)
+		wantSub[country.Region][country.Subregion] = sub
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("%d regions, want %d", len(got), len(want))
+	}
+	if !sort.SliceIsSorted(got, func(i, j int) bool { return got[i].Name < got[j].Name }) {
+		t.Error("regions not sorted by name")
+	}
+	for _, region := range got {
+		if region.GeoNode != want[region.Name] {
+			t.Errorf("region %+v, want %+v", region.GeoNode, want[region.Name])
+		}
+		if region.Subregions == nil {
+			t.Errorf("region %s: subregions encoded as null", region.Name)
+		}
+		if len(region.Subregions) != len(wantSub[region.Name]) {
+			t.Errorf("region %s: %d subregions, want %d", region.Name, len(region.Subregions), len(wantSub[region.Name]))
+		}
+		for _, sub := range region.Subregions {
+			if sub != wantSub[region.Name][sub.Name] {
+				t.Errorf("region %s: subregion %+v, want %+v", region.Name, sub, wantSub[region.Name][sub.Name])
+			}
+		}
+	}
+}
+
+func TestGetSubregionsByRegion(t *testing.T) {
+	loadTestCountries(t)
+
+	w := serve("/v1/regions/:region/subregions", GetSubregionsByRegion,
+		httptest.NewRequest(http.MethodGet, "/v1/regions/europe/subregions", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var subregions []GeoNode
+	if err := json.Unmarshal(w.Body.Bytes(), &subregions); err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, len(subregions))
+	for i, sub := range subregions {
+		names[i] = sub.Name
+	}
+	if !strings.Contains(strings.Join(names, ","), "Western Europe") {
+		t.Errorf("subregions of europe %q lack Western Europe", names)
+	}
+
+	w = serve("/v1/regions/:region/subregions", GetSubregionsByRegion,
+		httptest.NewRequest(http.MethodGet, "/v1/regions/atlantis/subregions", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("unknown region: status %d, want 404", w.Code)
+	}
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Code != CodeRegionNotFound || problem.Param != "region" {
+		t.Errorf("unknown region: problem %s on %q, want region_not_found on region", problem.Code, problem.Param)
+	}
+}
+
+func TestGetContinentsCountsEveryContinent(t *testing.T) {
+	countries := loadTestCountries(t)
+
+	w := serve("/v1/continents", GetContinents, httptest.NewRequest(http.MethodGet, "/v1/continents", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var got []GeoNode
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	// Countries spanning several continents, such as Russia, count towards each
+	memberships, total := 0, 0
+	for _, country := range countries {
+		memberships += len(country.Continents)
+	}
+	for _, continent := range got {
+		total += continent.CountryCount
+	}
+	if total != memberships || memberships <= len(countries) {
+		t.Errorf("continents count %d countries, want %d (over %d countries)", total, memberships, len(countries))
+	}
+}
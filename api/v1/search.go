@@ -0,0 +1,80 @@
+// search.go contains the country lookups shared by the REST, GraphQL and gRPC transports.
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SearchKeys are the filter keys accepted by SearchCountries.
+var SearchKeys = []string{
+	"independent", "name", "fullName", "currency", "demonym", "language", "capital",
+	"region", "subregion", "group", "continent", "translation",
+}
+
+// FindCountry returns the country with the given CCA2, CCA3, CCN3 or CIOC code, matched
+// case-insensitively as /alpha/{code} matches it.
+func FindCountry(code string) (Country, bool) {
+	for _, country := range Countries {
+		if strings.EqualFold(country.CCA2, code) ||
+			strings.EqualFold(country.CCA3, code) ||
+			strings.EqualFold(country.CCN3, code) ||
+			strings.EqualFold(country.CIOC, code) {
+			return country, true
+		}
+	}
+	return Country{}, false
+}
+
+// findCountryByCCN3 returns the country with the given ISO 3166-1 numeric code.
+func findCountryByCCN3(ccn3 string) (Country, bool) {
+	for _, country := range Countries {
+		if strings.EqualFold(country.CCN3, ccn3) {
+			return country, true
+		}
+	}
+	return Country{}, false
+}
+
+// findCountriesByCallingCode returns the countries whose IDD root and one of its suffixes form
+// the calling code, given without '+'.
+func findCountriesByCallingCode(callingCode string) []Country {
+	var matches []Country
+	for _, country := range Countries {
+		codeRoot := country.IDD.Root
+		for _, suffix := range country.IDD.Suffixes {
+			fullCode := strings.TrimSpace(codeRoot + suffix)
+			// Remove '+' for comparison
+			fullCode = strings.TrimPrefix(fullCode, "+")
+			if fullCode == callingCode {
+				matches = append(matches, country)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// SearchCountries returns the countries matching every filter, keyed by SearchKeys with the
+// values the REST search routes take. Unknown keys, unknown groups and independent values other
+// than "true" or "false" are errors.
+func SearchCountries(ctx context.Context, filters map[string]string) ([]Country, error) {
+	for key, value := range filters {
+		switch key {
+		case "independent":
+			if value != "true" && value != "false" {
+				return nil, fmt.Errorf("invalid boolean value: %s (must be 'true' or 'false')", value)
+			}
+		case "group":
+			if _, ok := findGroup(value); !ok {
+				return nil, fmt.Errorf("unknown group: %s", value)
+			}
+		default:
+			if !containsString(SearchKeys, key) {
+				return nil, fmt.Errorf("unknown filter: %s", key)
+			}
+		}
+	}
+	return filterCountries(ctx, filters), nil
+}
@@ -0,0 +1,253 @@
+// validation.go contains the strict request validation rules: allowed query parameters, code
+// formats per path parameter and field path checking with suggestions.
+package v1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// codeFormat describes the accepted shape of a code parameter.
+type codeFormat struct {
+	pattern *regexp.Regexp
+	want    string
+}
+
+// Code formats of the path and query parameters.
+var (
+	alphaCodeFormat   = codeFormat{regexp.MustCompile(`^[A-Za-z]{2,3}$`), "2 or 3 letters"}
+	countryCodeFormat = codeFormat{regexp.MustCompile(`^([A-Za-z]{2,3}|[0-9]{3})$`), "2 or 3 letters or 3 digits"}
+	ccn3CodeFormat    = codeFormat{regexp.MustCompile(`^[0-9]{3}$`), "3 digits"}
+	callingCodeFormat = codeFormat{regexp.MustCompile(`^[0-9]{1,6}$`), "1 to 6 digits without '+'"}
+	groupIDFormat     = codeFormat{regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`), "letters, digits, '_' or '-'"}
+)
+
+// routeRule lists what a route accepts in strict mode.
+type routeRule struct {
+	// query are the accepted query parameters besides "strict" and "envelope".
+	query []string
+	// fields enables validation of the fields parameter.
+	fields bool
+	// path maps path parameters to their required format.
+	path map[string]codeFormat
+	// codes enables validation of the comma-separated codes parameter.
+	codes bool
+}
+
+// Query parameters shared by the country routes.
+var (
+	countryQuery     = []string{"fields", "lang"}
+	countryListQuery = []string{"fields", "lang", "group"}
+)
+
+// routeRules holds the strict mode rules by route template, relative to /v1. Routes without
+// rules are not validated.
+var routeRules = map[string]routeRule{
+	"/all":                        {query: append(countryListQuery, "independent"), fields: true},
+	"/countries":                  {query: append(countryListQuery, "independent"), fields: true},
+	"/countries/:code":            {query: countryQuery, fields: true, path: map[string]codeFormat{"code": alphaCodeFormat}},
+	"/name/:name":                 {query: append(countryListQuery, "fullText"), fields: true},
+	"/alpha":                      {query: append(countryListQuery, "codes", "preserveOrder"), fields: true, codes: true},
+	"/currency/:currency":         {query: countryListQuery, fields: true},
+	"/demonym/:demonym":           {query: countryListQuery, fields: true},
+	"/lang/:language":             {query: countryListQuery, fields: true},
+	"/capital/:capital":           {query: countryListQuery, fields: true},
+	"/region/:region":             {query: countryListQuery, fields: true},
+	"/subregion/:subregion":       {query: countryListQuery, fields: true},
+	"/continent/:continent":       {query: countryListQuery, fields: true},
+	"/translation/:translation":   {query: countryListQuery, fields: true},
+	"/independent":                {query: append(countryListQuery, "status"), fields: true},
+	"/alpha/:code":                {query: countryQuery, fields: true, path: map[string]codeFormat{"code": countryCodeFormat}},
+	"/alpha/:code/memberships":    {path: map[string]codeFormat{"code": countryCodeFormat}},
+	"/ccn3/:code":                 {query: countryQuery, fields: true, path: map[string]codeFormat{"code": ccn3CodeFormat}},
+	"/callingcode/:callingcode":   {query: countryListQuery, fields: true, path: map[string]codeFormat{"callingcode": callingCodeFormat}},
+	"/languages":                  {},
+	"/languages/:code":            {path: map[string]codeFormat{"code": alphaCodeFormat}},
+	"/regions":                    {},
+	"/regions/:region/subregions": {},
+	"/continents":                 {},
+	"/groups":                     {},
+	"/groups/:id":                 {path: map[string]codeFormat{"id": groupIDFormat}},
+	"/locale/resolve":             {query: []string{"accept"}},
+	"/batch":                      {query: countryQuery, fields: true},
+}
+
+// MaxCodes caps the number of entries in the codes parameter in strict mode.
+var MaxCodes = 250
+
+// ValidateRequest checks the request against the strict rules of its route and returns every
+// problem found, or nil when the request is valid. routePrefix is stripped from the route
+// template before looking up the rules.
+func ValidateRequest(c *gin.Context, routePrefix string) []InvalidParam {
+	rule, ok := routeRules[strings.TrimPrefix(c.FullPath(), routePrefix)]
+	if !ok {
+		return nil
+	}
+
+	var problems []InvalidParam
+
+	query := c.Request.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	allowed := append([]string{"strict", "envelope"}, rule.query...)
+	for _, name := range names {
+		if !containsString(allowed, name) {
+			problems = append(problems, InvalidParam{
+				Param:      name,
+				Reason:     fmt.Sprintf("unknown query parameter '%s'", name),
+				Suggestion: suggest(name, allowed),
+			})
+		}
+	}
+
+	for _, param := range c.Params {
+		format, ok := rule.path[param.Key]
+		if ok && !format.pattern.MatchString(param.Value) {
+			problems = append(problems, InvalidParam{
+				Param:  param.Key,
+				Reason: fmt.Sprintf("'%s' is not a valid %s: expected %s", param.Value, param.Key, format.want),
+			})
+		}
+	}
+
+	if rule.codes {
+		problems = append(problems, validateCodes(c.Query("codes"))...)
+	}
+	if rule.fields {
+		if fields := c.Query("fields"); fields != "" {
+			problems = append(problems, validateFields(fields)...)
+		}
+	}
+	return problems
+}
+
+// validateCodes checks the size of the codes list and the format of every entry, reporting both
+// an oversized list and the malformed entries in it.
+func validateCodes(codes string) []InvalidParam {
+	if codes == "" {
+		return nil
+	}
+	list := strings.Split(codes, ",")
+
+	var problems []InvalidParam
+	if len(list) > MaxCodes {
+		problems = append(problems, InvalidParam{
+			Param:  "codes",
+			Reason: fmt.Sprintf("%d codes given, at most %d are allowed", len(list), MaxCodes),
+		})
+	}
+	for i, code := range list {
+		code = strings.TrimSpace(code)
+		if !countryCodeFormat.pattern.MatchString(code) {
+			problems = append(problems, InvalidParam{
+				Param:  "codes",
+				Reason: fmt.Sprintf("code %d '%s' is not valid: expected %s", i+1, code, countryCodeFormat.want),
+			})
+		}
+	}
+	return problems
+}
+
+// validateFields checks that every comma-separated field path, inclusion or "-" exclusion,
+// exists on Country, suggesting the closest name for misspelled segments.
+func validateFields(fields string) []InvalidParam {
+	var problems []InvalidParam
+	for _, field := range strings.Split(fields, ",") {
+		path := strings.TrimPrefix(strings.TrimSpace(field), "-")
+		if path == "" {
+			problems = append(problems, InvalidParam{Param: "fields", Reason: "empty field name"})
+			continue
+		}
+		if reason, suggestion := checkFieldPath(countrySchema, strings.Split(path, "."), ""); reason != "" {
+			problems = append(problems, InvalidParam{Param: "fields", Reason: reason, Suggestion: suggestion})
+		}
+	}
+	return problems
+}
+
+// checkFieldPath walks a path through the schema s as a projection would, with walked holding
+// the segments already checked. It returns an empty reason for valid paths. Any key is accepted
+// below a map, since map keys are data.
+func checkFieldPath(s *fieldSchema, parts []string, walked string) (reason, suggestion string) {
+	if len(parts) == 0 {
+		return "", ""
+	}
+
+	part := parts[0]
+	switch {
+	case s == nil:
+		return fmt.Sprintf("field '%s' has no subfields", strings.TrimSuffix(walked, ".")), ""
+	case s.isMap():
+		return checkFieldPath(s.elem, parts[1:], walked+part+".")
+	case part == "*":
+		for _, m := range s.members {
+			if reason, _ := checkFieldPath(m.schema, parts[1:], walked+"*."); reason == "" {
+				return "", ""
+			}
+		}
+		return fmt.Sprintf("no field matches '%s'", walked+strings.Join(parts, ".")), ""
+	}
+	m, ok := s.byName[strings.ToLower(part)]
+	if !ok {
+		return fmt.Sprintf("unknown field '%s'", walked+part), suggestPath(walked, part, s.memberNames())
+	}
+	return checkFieldPath(m.schema, parts[1:], walked+part+".")
+}
+
+// memberNames returns the JSON names of the struct members of s.
+func (s *fieldSchema) memberNames() []string {
+	names := make([]string, len(s.members))
+	for i, m := range s.members {
+		names[i] = m.name
+	}
+	return names
+}
+
+// suggestPath returns the suggestion for a misspelled path segment, prefixed with its parents.
+func suggestPath(parent, part string, candidates []string) string {
+	if s := suggest(part, candidates); s != "" {
+		return parent + s
+	}
+	return ""
+}
+
+// suggest returns the candidate closest to input by edit distance, or "" when none is close
+// enough to be a plausible typo.
+func suggest(input string, candidates []string) string {
+	best, bestDistance := "", len(input)/3+2
+	for _, candidate := range candidates {
+		if d := editDistance(strings.ToLower(input), strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
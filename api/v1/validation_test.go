@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCodesReportsSizeAndFormat(t *testing.T) {
+	defer func(max int) { MaxCodes = max }(MaxCodes)
+	MaxCodes = 2
+
+	problems := validateCodes("DE,x1,FR")
+	if len(problems) != 2 {
+		t.Fatalf("got %d problems %+v, want the list size and the malformed code", len(problems), problems)
+	}
+	if !strings.Contains(problems[0].Reason, "3 codes given") {
+		t.Errorf("first problem %q, want the list size", problems[0].Reason)
+	}
+	if !strings.Contains(problems[1].Reason, "code 2 'x1'") {
+		t.Errorf("second problem %q, want the malformed code", problems[1].Reason)
+	}
+
+	if problems := validateCodes("DE,FR"); len(problems) != 0 {
+		t.Errorf("valid list: unexpected problems %+v", problems)
+	}
+}
+
+func TestValidateFieldsSuggestsClosestName(t *testing.T) {
+	for _, tc := range []struct {
+		fields, reason, suggestion string
+	}{
+		{"populaton", "unknown field 'populaton'", "population"},
+		{"-captial", "unknown field 'captial'", "capital"},
+		{"name.comon", "unknown field 'name.comon'", "name.common"},
+		{"cca3,flgs", "unknown field 'flgs'", "flags"},
+		{"zzzzzzzz", "unknown field 'zzzzzzzz'", ""},
+		{"cca3,", "empty field name", ""},
+	} {
+		problems := validateFields(tc.fields)
+		if len(problems) != 1 {
+			t.Errorf("%s: got problems %+v, want one", tc.fields, problems)
+			continue
+		}
+		if problems[0].Param != "fields" || problems[0].Reason != tc.reason || problems[0].Suggestion != tc.suggestion {
+			t.Errorf("%s: got %+v, want %q suggesting %q", tc.fields, problems[0], tc.reason, tc.suggestion)
+		}
+	}
+
+	for _, fields := range []string{"cca3,name.common", "-flags", "translations.deu.common", "translations.*.common", "currencies.eur.symbol"} {
+		if problems := validateFields(fields); len(problems) != 0 {
+			t.Errorf("%s: unexpected problems %+v", fields, problems)
+		}
+	}
+}
@@ -0,0 +1,599 @@
+// config.go contains the typed server configuration, loaded from a YAML or TOML file plus environment overrides.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// Duration is a time.Duration written as a Go duration string (e.g. "15s") in config files and env vars.
+type Duration time.Duration
+
+// UnmarshalText parses a duration string.
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// String formats the duration like time.Duration.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// ServerConfig holds the HTTP listener settings.
+type ServerConfig struct {
+	Addr              string   `yaml:"addr" toml:"addr"`
+	ReadTimeout       Duration `yaml:"read_timeout" toml:"read_timeout"`
+	ReadHeaderTimeout Duration `yaml:"read_header_timeout" toml:"read_header_timeout"`
+	WriteTimeout      Duration `yaml:"write_timeout" toml:"write_timeout"`
+	IdleTimeout       Duration `yaml:"idle_timeout" toml:"idle_timeout"`
+	MaxHeaderBytes    int      `yaml:"max_header_bytes" toml:"max_header_bytes"`
+	ShutdownTimeout   Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+	TrustedProxies    []string `yaml:"trusted_proxies" toml:"trusted_proxies"`
+}
+
+// DataConfig holds the paths of the bundled datasets.
+type DataConfig struct {
+	Countries string `yaml:"countries" toml:"countries"`
+	Languages string `yaml:"languages" toml:"languages"`
+	Groups    string `yaml:"groups" toml:"groups"`
+}
+
+// SwaggerConfig holds the host and schemes advertised in the Swagger documentation.
+type SwaggerConfig struct {
+	Host    string   `yaml:"host" toml:"host"`
+	Schemes []string `yaml:"schemes" toml:"schemes"`
+}
+
+// CORSConfig holds the cross-origin settings. An origin of "*" allows every origin.
+type CORSConfig struct {
+	AllowOrigins []string `yaml:"allow_origins" toml:"allow_origins"`
+	AllowMethods []string `yaml:"allow_methods" toml:"allow_methods"`
+	AllowHeaders []string `yaml:"allow_headers" toml:"allow_headers"`
+}
+
+// LogConfig holds the logging settings.
+type LogConfig struct {
+	Level  string `yaml:"level" toml:"level"`
+	Format string `yaml:"format" toml:"format"`
+}
+
+// TracingConfig holds the OpenTelemetry settings. An empty exporter disables tracing.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" toml:"exporter"`
+	Endpoint    string  `yaml:"endpoint" toml:"endpoint"`
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio"`
+}
+
+// AuthConfig holds the optional API key and rate limit files. Empty paths disable the feature.
+type AuthConfig struct {
+	KeysFile       string `yaml:"keys_file" toml:"keys_file"`
+	RateLimitsFile string `yaml:"rate_limits_file" toml:"rate_limits_file"`
+}
+
+// CacheRoute overrides the Cache-Control header for one route template, e.g. "/v1/alpha/:code".
+type CacheRoute struct {
+	Route        string `yaml:"route" toml:"route"`
+	CacheControl string `yaml:"cache_control" toml:"cache_control"`
+}
+
+// CacheConfig holds the Cache-Control headers sent with successful /v1 responses. An empty
+// value omits the header; ETag and Last-Modified are always sent.
+type CacheConfig struct {
+	Default string       `yaml:"default" toml:"default"`
+	Routes  []CacheRoute `yaml:"routes" toml:"routes"`
+}
+
+// ErrorsConfig selects the error response shape: "problem" for RFC 7807 problem details or
+// "legacy" for the original {"message": ...} body.
+type ErrorsConfig struct {
+	Format string `yaml:"format" toml:"format"`
+}
+
+// StrictRoute overrides strict validation for one route template, e.g. "/v1/alpha".
+type StrictRoute struct {
+	Route  string `yaml:"route" toml:"route"`
+	Strict bool   `yaml:"strict" toml:"strict"`
+}
+
+// ValidationConfig holds the strict request validation settings. Strict mode rejects unknown
+// query parameters and fields, malformed codes and code lists longer than MaxCodes. MaxBatch caps
+// the lookups of a /v1/batch request in every mode.
+type ValidationConfig struct {
+	Strict   bool          `yaml:"strict" toml:"strict"`
+	MaxCodes int           `yaml:"max_codes" toml:"max_codes"`
+	MaxBatch int           `yaml:"max_batch" toml:"max_batch"`
+	Routes   []StrictRoute `yaml:"routes" toml:"routes"`
+}
+
+// GraphQLConfig controls the /graphql endpoint and its GraphiQL page. Queries nesting deeper
+// than MaxDepth or estimated to resolve more than MaxComplexity fields are rejected.
+type GraphQLConfig struct {
+	Enabled       bool `yaml:"enabled" toml:"enabled"`
+	GraphiQL      bool `yaml:"graphiql" toml:"graphiql"`
+	MaxDepth      int  `yaml:"max_depth" toml:"max_depth"`
+	MaxComplexity int  `yaml:"max_complexity" toml:"max_complexity"`
+}
+
+// CompatConfig controls the restcountries.com v3.1 compatibility routes under /v3.1.
+type CompatConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+}
+
+// GRPCConfig holds the gRPC listener. An empty Addr disables the gRPC server.
+type GRPCConfig struct {
+	Addr string `yaml:"addr" toml:"addr"`
+}
+
+// Config is the complete server configuration.
+type Config struct {
+	Env        string           `yaml:"env" toml:"env"`
+	Server     ServerConfig     `yaml:"server" toml:"server"`
+	Data       DataConfig       `yaml:"data" toml:"data"`
+	Swagger    SwaggerConfig    `yaml:"swagger" toml:"swagger"`
+	CORS       CORSConfig       `yaml:"cors" toml:"cors"`
+	Log        LogConfig        `yaml:"log" toml:"log"`
+	Tracing    TracingConfig    `yaml:"tracing" toml:"tracing"`
+	Auth       AuthConfig       `yaml:"auth" toml:"auth"`
+	Cache      CacheConfig      `yaml:"cache" toml:"cache"`
+	Errors     ErrorsConfig     `yaml:"errors" toml:"errors"`
+	Validation ValidationConfig `yaml:"validation" toml:"validation"`
+	GraphQL    GraphQLConfig    `yaml:"graphql" toml:"graphql"`
+	GRPC       GRPCConfig       `yaml:"grpc" toml:"grpc"`
+	Compat     CompatConfig     `yaml:"compat" toml:"compat"`
+}
+
+// Default returns the built-in configuration, matching the server's behavior without a config file.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:              ":3101",
+			ReadTimeout:       Duration(15 * time.Second),
+			ReadHeaderTimeout: Duration(5 * time.Second),
+			WriteTimeout:      Duration(30 * time.Second),
+			IdleTimeout:       Duration(120 * time.Second),
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   Duration(20 * time.Second),
+		},
+		Data: DataConfig{
+			Countries: "data/countries.json",
+			Languages: "data/languages.json",
+			Groups:    "data/groups.json",
+		},
+		Swagger: SwaggerConfig{
+			Schemes: []string{"https", "http"},
+		},
+		CORS: CORSConfig{
+			AllowOrigins: []string{"*"},
+			AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+			AllowHeaders: []string{"Origin", "Content-Length", "Content-Type", "Accept-Language", "dapi-key", "traceparent", "tracestate", "X-Request-ID"},
+		},
+		Tracing: TracingConfig{
+			SampleRatio: 1,
+		},
+		Cache: CacheConfig{
+			Default: "public, max-age=3600",
+		},
+		Errors: ErrorsConfig{
+			Format: "problem",
+		},
+		Validation: ValidationConfig{
+			MaxCodes: 250,
+			MaxBatch: 500,
+		},
+		GraphQL: GraphQLConfig{
+			Enabled:       true,
+			GraphiQL:      true,
+			MaxDepth:      6,
+			MaxComplexity: 25000,
+		},
+		Compat: CompatConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the optional file (YAML or TOML, chosen by
+// extension) and environment overrides, in that order, and validates the result.
+func Load(filename string) (Config, error) {
+	cfg := Default()
+
+	if filename != "" {
+		if err := cfg.loadFile(filename); err != nil {
+			return cfg, err
+		}
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return cfg, err
+	}
+	cfg.applyEnvDefaults()
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// loadFile decodes the config file over the current values. Unknown keys are errors, so a
+// misspelled option fails loudly instead of silently keeping its default.
+func (cfg *Config) loadFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err = dec.Decode(cfg); errors.Is(err, io.EOF) {
+			// An empty file keeps the defaults
+			err = nil
+		}
+	case ".toml":
+		err = toml.NewDecoder(bytes.NewReader(data)).DisallowUnknownFields().Decode(cfg)
+		var strict *toml.StrictMissingError
+		if errors.As(err, &strict) {
+			keys := make([]string, len(strict.Errors))
+			for i, e := range strict.Errors {
+				keys[i] = strings.Join(e.Key(), ".")
+			}
+			err = fmt.Errorf("unknown keys: %s", strings.Join(keys, ", "))
+		}
+	default:
+		return fmt.Errorf("unsupported config file extension %q (use .yaml, .yml or .toml)", filepath.Ext(filename))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", filename, err)
+	}
+	return nil
+}
+
+// applyEnv overrides values from environment variables. PORT is kept for compatibility and
+// sets the listen address to ":<PORT>" unless ATLAS_ADDR is also given.
+func (cfg *Config) applyEnv() error {
+	setString := func(name string, dst *string) {
+		if v := os.Getenv(name); v != "" {
+			*dst = v
+		}
+	}
+	setList := func(name string, dst *[]string) {
+		if v := os.Getenv(name); v != "" {
+			*dst = splitList(v)
+		}
+	}
+	var errs []error
+	setInt := func(name string, dst *int) {
+		if v := os.Getenv(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: must be an integer", name))
+				return
+			}
+			*dst = n
+		}
+	}
+	setBool := func(name string, dst *bool) {
+		if v := os.Getenv(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: must be true or false", name))
+				return
+			}
+			*dst = b
+		}
+	}
+	setFloat := func(name string, dst *float64) {
+		if v := os.Getenv(name); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: must be a number", name))
+				return
+			}
+			*dst = f
+		}
+	}
+	setDuration := func(name string, dst *Duration) {
+		if v := os.Getenv(name); v != "" {
+			if err := dst.UnmarshalText([]byte(v)); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+	}
+
+	setString("ATLAS_ENV", &cfg.Env)
+	if port := os.Getenv("PORT"); port != "" {
+		cfg.Server.Addr = ":" + port
+	}
+	setString("ATLAS_ADDR", &cfg.Server.Addr)
+	setDuration("ATLAS_READ_TIMEOUT", &cfg.Server.ReadTimeout)
+	setDuration("ATLAS_READ_HEADER_TIMEOUT", &cfg.Server.ReadHeaderTimeout)
+	setDuration("ATLAS_WRITE_TIMEOUT", &cfg.Server.WriteTimeout)
+	setDuration("ATLAS_IDLE_TIMEOUT", &cfg.Server.IdleTimeout)
+	setInt("ATLAS_MAX_HEADER_BYTES", &cfg.Server.MaxHeaderBytes)
+	setDuration("ATLAS_SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout)
+	setList("ATLAS_TRUSTED_PROXIES", &cfg.Server.TrustedProxies)
+	setString("ATLAS_DATA_COUNTRIES", &cfg.Data.Countries)
+	setString("ATLAS_DATA_LANGUAGES", &cfg.Data.Languages)
+	setString("ATLAS_DATA_GROUPS", &cfg.Data.Groups)
+	setString("ATLAS_SWAGGER_HOST", &cfg.Swagger.Host)
+	setList("ATLAS_SWAGGER_SCHEMES", &cfg.Swagger.Schemes)
+	setList("ATLAS_CORS_ORIGINS", &cfg.CORS.AllowOrigins)
+	setList("ATLAS_CORS_METHODS", &cfg.CORS.AllowMethods)
+	setList("ATLAS_CORS_HEADERS", &cfg.CORS.AllowHeaders)
+	setString("ATLAS_LOG_LEVEL", &cfg.Log.Level)
+	setString("ATLAS_LOG_FORMAT", &cfg.Log.Format)
+	setString("ATLAS_TRACING_EXPORTER", &cfg.Tracing.Exporter)
+	setString("ATLAS_TRACING_ENDPOINT", &cfg.Tracing.Endpoint)
+	setFloat("ATLAS_TRACING_SAMPLE_RATIO", &cfg.Tracing.SampleRatio)
+	setString("API_KEYS_FILE", &cfg.Auth.KeysFile)
+	setString("RATE_LIMITS_FILE", &cfg.Auth.RateLimitsFile)
+	setString("ATLAS_CACHE_CONTROL", &cfg.Cache.Default)
+	setString("ATLAS_ERROR_FORMAT", &cfg.Errors.Format)
+	setBool("ATLAS_STRICT", &cfg.Validation.Strict)
+	setInt("ATLAS_MAX_CODES", &cfg.Validation.MaxCodes)
+	setInt("ATLAS_MAX_BATCH", &cfg.Validation.MaxBatch)
+	setBool("ATLAS_GRAPHQL", &cfg.GraphQL.Enabled)
+	setBool("ATLAS_GRAPHIQL", &cfg.GraphQL.GraphiQL)
+	setInt("ATLAS_GRAPHQL_MAX_DEPTH", &cfg.GraphQL.MaxDepth)
+	setInt("ATLAS_GRAPHQL_MAX_COMPLEXITY", &cfg.GraphQL.MaxComplexity)
+	setBool("ATLAS_COMPAT", &cfg.Compat.Enabled)
+	setString("ATLAS_GRPC_ADDR", &cfg.GRPC.Addr)
+
+	return errors.Join(errs...)
+}
+
+// applyEnvDefaults fills in values that depend on Env when they were not set explicitly.
+func (cfg *Config) applyEnvDefaults() {
+	if cfg.Swagger.Host == "" {
+		cfg.Swagger.Host = defaultHost(cfg.Env)
+	}
+	if cfg.Log.Level == "" {
+		cfg.Log.Level = "debug"
+		if cfg.Env == "production" {
+			cfg.Log.Level = "info"
+		}
+	}
+	if cfg.Log.Format == "" {
+		cfg.Log.Format = "text"
+		if cfg.Env == "production" {
+			cfg.Log.Format = "json"
+		}
+	}
+}
+
+// defaultHost maps ATLAS_ENV to the public host name of each deployment.
+func defaultHost(env string) string {
+	switch env {
+	case "production":
+		return "atlas.doroad.dev"
+	case "test":
+		return "atlas-test.doroad.dev"
+	case "dev":
+		return "atlas-dev.doroad.dev"
+	case "core":
+		return "gcr.doroad.dev"
+	default:
+		return "localhost:3101"
+	}
+}
+
+// Validate checks every setting and reports all problems at once.
+func (cfg Config) Validate() error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(cfg.Server.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("server.addr %q: must be host:port", cfg.Server.Addr))
+	}
+	for _, timeout := range []struct {
+		name  string
+		value Duration
+	}{
+		{"server.read_timeout", cfg.Server.ReadTimeout},
+		{"server.read_header_timeout", cfg.Server.ReadHeaderTimeout},
+		{"server.write_timeout", cfg.Server.WriteTimeout},
+		{"server.idle_timeout", cfg.Server.IdleTimeout},
+	} {
+		if timeout.value < 0 {
+			errs = append(errs, fmt.Errorf("%s: must not be negative", timeout.name))
+		}
+	}
+	if cfg.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout: must be positive"))
+	}
+	if cfg.Server.MaxHeaderBytes <= 0 {
+		errs = append(errs, errors.New("server.max_header_bytes: must be positive"))
+	}
+	if cfg.GRPC.Addr != "" {
+		if _, _, err := net.SplitHostPort(cfg.GRPC.Addr); err != nil {
+			errs = append(errs, fmt.Errorf("grpc.addr %q: must be host:port", cfg.GRPC.Addr))
+		} else if cfg.GRPC.Addr == cfg.Server.Addr {
+			errs = append(errs, fmt.Errorf("grpc.addr %q: must differ from server.addr", cfg.GRPC.Addr))
+		}
+	}
+	for _, proxy := range cfg.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errs = append(errs, fmt.Errorf("server.trusted_proxies %q: must be an IP address or CIDR", proxy))
+			}
+		}
+	}
+
+	for _, file := range []struct{ name, path string }{
+		{"data.countries", cfg.Data.Countries},
+		{"data.languages", cfg.Data.Languages},
+		{"data.groups", cfg.Data.Groups},
+	} {
+		if file.path == "" {
+			errs = append(errs, fmt.Errorf("%s: path is required", file.name))
+		} else if _, err := os.Stat(file.path); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file.name, err))
+		}
+	}
+
+	if len(cfg.Swagger.Schemes) == 0 {
+		errs = append(errs, errors.New("swagger.schemes: at least one scheme is required"))
+	}
+	for _, scheme := range cfg.Swagger.Schemes {
+		if scheme != "http" && scheme != "https" {
+			errs = append(errs, fmt.Errorf("swagger.schemes %q: must be http or https", scheme))
+		}
+	}
+
+	if len(cfg.CORS.AllowOrigins) == 0 {
+		errs = append(errs, errors.New("cors.allow_origins: at least one origin is required"))
+	}
+	for _, origin := range cfg.CORS.AllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("cors.allow_origins %q: must be \"*\" or scheme://host[:port]", origin))
+		}
+	}
+	for _, method := range cfg.CORS.AllowMethods {
+		if !validMethods[strings.ToUpper(method)] {
+			errs = append(errs, fmt.Errorf("cors.allow_methods %q: unknown HTTP method", method))
+		}
+	}
+
+	switch cfg.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level %q: must be debug, info, warn or error", cfg.Log.Level))
+	}
+	if cfg.Log.Format != "json" && cfg.Log.Format != "text" {
+		errs = append(errs, fmt.Errorf("log.format %q: must be json or text", cfg.Log.Format))
+	}
+
+	switch cfg.Tracing.Exporter {
+	case "", "otlp", "stdout":
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter %q: must be otlp, stdout or empty", cfg.Tracing.Exporter))
+	}
+	if cfg.Tracing.Endpoint != "" {
+		if u, err := url.Parse(cfg.Tracing.Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("tracing.endpoint %q: must be a URL such as http://localhost:4318", cfg.Tracing.Endpoint))
+		}
+	}
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio %v: must be between 0 and 1", cfg.Tracing.SampleRatio))
+	}
+
+	for _, file := range []struct{ name, path string }{
+		{"auth.keys_file", cfg.Auth.KeysFile},
+		{"auth.rate_limits_file", cfg.Auth.RateLimitsFile},
+	} {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.path); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file.name, err))
+		}
+	}
+
+	seenRoutes := make(map[string]bool)
+	for _, route := range cfg.Cache.Routes {
+		if !strings.HasPrefix(route.Route, "/") {
+			errs = append(errs, fmt.Errorf("cache.routes %q: route must be a template starting with /", route.Route))
+		} else if seenRoutes[route.Route] {
+			errs = append(errs, fmt.Errorf("cache.routes %q: duplicate route", route.Route))
+		}
+		seenRoutes[route.Route] = true
+	}
+
+	if cfg.Errors.Format != "problem" && cfg.Errors.Format != "legacy" {
+		errs = append(errs, fmt.Errorf("errors.format %q: must be problem or legacy", cfg.Errors.Format))
+	}
+
+	if cfg.Validation.MaxCodes <= 0 {
+		errs = append(errs, errors.New("validation.max_codes: must be positive"))
+	}
+	if cfg.Validation.MaxBatch <= 0 {
+		errs = append(errs, errors.New("validation.max_batch: must be positive"))
+	}
+	if cfg.GraphQL.MaxDepth <= 0 {
+		errs = append(errs, errors.New("graphql.max_depth: must be positive"))
+	}
+	if cfg.GraphQL.MaxComplexity <= 0 {
+		errs = append(errs, errors.New("graphql.max_complexity: must be positive"))
+	}
+	seenStrict := make(map[string]bool)
+	for _, route := range cfg.Validation.Routes {
+		if !strings.HasPrefix(route.Route, "/") {
+			errs = append(errs, fmt.Errorf("validation.routes %q: route must be a template starting with /", route.Route))
+		} else if seenStrict[route.Route] {
+			errs = append(errs, fmt.Errorf("validation.routes %q: duplicate route", route.Route))
+		}
+		seenStrict[route.Route] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// RouteMap returns the per-route strict settings keyed by route template.
+func (v ValidationConfig) RouteMap() map[string]bool {
+	routes := make(map[string]bool, len(v.Routes))
+	for _, route := range v.Routes {
+		routes[route.Route] = route.Strict
+	}
+	return routes
+}
+
+// RouteMap returns the per-route Cache-Control values keyed by route template.
+func (c CacheConfig) RouteMap() map[string]string {
+	routes := make(map[string]string, len(c.Routes))
+	for _, route := range c.Routes {
+		routes[route.Route] = route.CacheControl
+	}
+	return routes
+}
+
+// AllowsAllOrigins reports whether CORS is open to every origin.
+func (c CORSConfig) AllowsAllOrigins() bool {
+	for _, origin := range c.AllowOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// validMethods lists the HTTP methods accepted in cors.allow_methods.
+var validMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// splitList splits a comma-separated env value, trimming blanks.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes content to a file with the given name in a temporary directory.
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLoadFileRejectsUnknownKeys(t *testing.T) {
+	for name, content := range map[string]string{
+		"config.yaml": "server:\n  read_timout: 5s\n",
+		"config.toml": "[server]\nread_timout = \"5s\"\n",
+	} {
+		cfg := Default()
+		err := cfg.loadFile(writeConfig(t, name, content))
+		if err == nil || !strings.Contains(err.Error(), "read_timout") {
+			t.Errorf("%s with a misspelled key: error %v, want one naming read_timout", name, err)
+		}
+	}
+}
+
+func TestLoadFileAcceptsExample(t *testing.T) {
+	for _, file := range []string{"../config.example.yaml", writeConfig(t, "empty.yaml", "")} {
+		cfg := Default()
+		if err := cfg.loadFile(file); err != nil {
+			t.Errorf("%s: %v", file, err)
+		}
+	}
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+	for _, tc := range []struct {
+		name, port, addr string
+		want             string
+	}{
+		{"neither", "", "", ":3101"},
+		{"PORT", "8080", "", ":8080"},
+		{"ATLAS_ADDR", "", "127.0.0.1:9000", "127.0.0.1:9000"},
+		{"ATLAS_ADDR wins over PORT", "8080", "127.0.0.1:9000", "127.0.0.1:9000"},
+	} {
+		t.Setenv("PORT", tc.port)
+		t.Setenv("ATLAS_ADDR", tc.addr)
+		cfg := Default()
+		if err := cfg.applyEnv(); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if cfg.Server.Addr != tc.want {
+			t.Errorf("%s: addr %q, want %q", tc.name, cfg.Server.Addr, tc.want)
+		}
+	}
+
+	t.Setenv("ATLAS_READ_TIMEOUT", "2s")
+	t.Setenv("ATLAS_STRICT", "true")
+	t.Setenv("ATLAS_MAX_CODES", "10")
+	t.Setenv("ATLAS_TRACING_SAMPLE_RATIO", "0.25")
+	t.Setenv("ATLAS_CORS_ORIGINS", "https://a.example, https://b.example")
+	cfg := Default()
+	if err := cfg.applyEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.ReadTimeout != Duration(2*time.Second) || !cfg.Validation.Strict || cfg.Validation.MaxCodes != 10 ||
+		cfg.Tracing.SampleRatio != 0.25 || !reflect.DeepEqual(cfg.CORS.AllowOrigins, []string{"https://a.example", "https://b.example"}) {
+		t.Errorf("overrides not applied: timeout %v, strict %v, max codes %d, sample ratio %v, origins %q",
+			cfg.Server.ReadTimeout, cfg.Validation.Strict, cfg.Validation.MaxCodes, cfg.Tracing.SampleRatio, cfg.CORS.AllowOrigins)
+	}
+}
+
+func TestApplyEnvRejectsMalformedValues(t *testing.T) {
+	bad := map[string]string{
+		"ATLAS_READ_TIMEOUT":         "5 seconds",
+		"ATLAS_STRICT":               "yes please",
+		"ATLAS_MAX_CODES":            "many",
+		"ATLAS_TRACING_SAMPLE_RATIO": "half",
+	}
+	for name, value := range bad {
+		t.Setenv(name, value)
+	}
+	cfg := Default()
+	err := cfg.applyEnv()
+	if err == nil {
+		t.Fatal("malformed values applied without an error")
+	}
+	// Every malformed variable is reported, not just the first
+	for name := range bad {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q does not name %s", err, name)
+		}
+	}
+	if cfg.Server.ReadTimeout != Default().Server.ReadTimeout || cfg.Validation.Strict {
+		t.Error("malformed values replaced the defaults")
+	}
+}
+
+func TestApplyEnvDefaults(t *testing.T) {
+	for _, tc := range []struct {
+		env                 string
+		host, level, format string
+	}{
+		{"", "localhost:3101", "debug", "text"},
+		{"dev", "atlas-dev.doroad.dev", "debug", "text"},
+		{"production", "atlas.doroad.dev", "info", "json"},
+	} {
+		cfg := Default()
+		cfg.Env = tc.env
+		cfg.applyEnvDefaults()
+		if cfg.Swagger.Host != tc.host || cfg.Log.Level != tc.level || cfg.Log.Format != tc.format {
+			t.Errorf("env %q: host %q, level %q, format %q; want %q, %q, %q",
+				tc.env, cfg.Swagger.Host, cfg.Log.Level, cfg.Log.Format, tc.host, tc.level, tc.format)
+		}
+	}
+
+	// Explicit values are kept
+	cfg := Default()
+	cfg.Env = "production"
+	cfg.Swagger.Host = "api.example.com"
+	cfg.Log.Level = "warn"
+	cfg.applyEnvDefaults()
+	if cfg.Swagger.Host != "api.example.com" || cfg.Log.Level != "warn" {
+		t.Errorf("explicit host %q and level %q overwritten", cfg.Swagger.Host, cfg.Log.Level)
+	}
+}
+
+func TestValidateReportsEveryError(t *testing.T) {
+	valid := Default()
+	valid.Data = DataConfig{Countries: "../data/countries.json", Languages: "../data/languages.json", Groups: "../data/groups.json"}
+	valid.applyEnvDefaults()
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("default configuration: %v", err)
+	}
+
+	cfg := valid
+	cfg.Server.Addr = "3101"
+	cfg.Server.ShutdownTimeout = 0
+	cfg.Log.Level = "verbose"
+	cfg.Errors.Format = "xml"
+	cfg.Tracing.SampleRatio = 2
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("invalid configuration passed validation")
+	}
+	for _, key := range []string{"server.addr", "server.shutdown_timeout", "log.level", "errors.format", "tracing.sample_ratio"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error %q does not report %s", err, key)
+		}
+	}
+}
@@ -26,7 +26,7 @@ const docTemplate = `{
     "paths": {
         "/alpha": {
             "get": {
-                "description": "Get countries matching a list of codes (CCA2, CCN3, CCA3, or CIOC).",
+                "description": "Get countries matching a list of codes (CCA2, CCN3, CCA3, or CIOC). Results are in dataset order unless preserveOrder=true, which returns one entry per requested code in request order, duplicates included, with null for codes that match no country or are outside the requested group. Unmatched codes are listed in the X-Not-Found header.",
                 "consumes": [
                     "application/json"
                 ],
@@ -45,11 +45,29 @@ const docTemplate = `{
                         "in": "query",
                         "required": true
                     },
+                    {
+                        "type": "boolean",
+                        "description": "Return results aligned to the requested codes",
+                        "name": "preserveOrder",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
                         "name": "fields",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -60,20 +78,32 @@ const docTemplate = `{
                             "items": {
                                 "$ref": "#/definitions/v1.Country"
                             }
+                        },
+                        "headers": {
+                            "X-Not-Found": {
+                                "type": "string",
+                                "description": "Requested codes matching no country"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/capital/{capital}": {
+        "/alpha/{code}/memberships": {
             "get": {
-                "description": "Get countries matching a capital city name.",
+                "description": "Get the groups a country (CCA2, CCN3, CCA3 or CIOC code) belongs to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -81,22 +111,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Countries"
+                    "Groups"
                 ],
-                "summary": "Get countries by capital",
+                "summary": "Get group memberships of a country",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Capital city name",
-                        "name": "capital",
+                        "description": "Country code (CCA2, CCN3, CCA3, CIOC)",
+                        "name": "code",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
-                        "name": "fields",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -105,22 +129,22 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/v1.Country"
+                                "$ref": "#/definitions/v1.Membership"
                             }
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/ccn3/{code}": {
-            "get": {
-                "description": "Get details of a specific country by its numeric ISO code.",
+        "/batch": {
+            "post": {
+                "description": "Run up to validation.max_batch lookups of different kinds in one request, e.g. [{\"alpha\":\"DE\"},{\"name\":\"Japan\",\"fullText\":true},{\"callingcode\":\"44\"},{\"currency\":\"EUR\"}]. The response has one result per lookup in request order, with the status the equivalent route would return and either data or a problem. fields and lang apply to every lookup.",
                 "consumes": [
                     "application/json"
                 ],
@@ -130,41 +154,61 @@ const docTemplate = `{
                 "tags": [
                     "Countries"
                 ],
-                "summary": "Get country by numeric ISO code (CCN3)",
+                "summary": "Batch lookup",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Numeric code (e.g., 840)",
-                        "name": "code",
-                        "in": "path",
-                        "required": true
+                        "description": "Lookups, each setting exactly one lookup member",
+                        "name": "lookups",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.BatchLookup"
+                            }
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
                         "name": "fields",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.Country"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.BatchResult"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/countries": {
+        "/capital/{capital}": {
             "get": {
-                "description": "Get details of all countries, with optional filters.",
+                "description": "Get countries matching a capital city name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -174,19 +218,32 @@ const docTemplate = `{
                 "tags": [
                     "Countries"
                 ],
-                "summary": "Get all countries",
+                "summary": "Get countries by capital",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by independent status (true or false)",
-                        "name": "independent",
-                        "in": "query"
+                        "description": "Capital city name",
+                        "name": "capital",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
                         "name": "fields",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -202,15 +259,21 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/countries/{code}": {
+        "/ccn3/{code}": {
             "get": {
-                "description": "Get details of a specific country by its code (CCA2 or CCA3).",
+                "description": "Get details of a specific country by its numeric ISO code.",
                 "consumes": [
                     "application/json"
                 ],
@@ -220,20 +283,26 @@ const docTemplate = `{
                 "tags": [
                     "Countries"
                 ],
-                "summary": "Get country by code",
+                "summary": "Get country by numeric ISO code (CCN3)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Country code (CCA2 or CCA3)",
+                        "description": "Numeric code (e.g., 840)",
                         "name": "code",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
                         "name": "fields",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -243,18 +312,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/v1.Country"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/currency/{currency}": {
+        "/continent/{continent}": {
             "get": {
-                "description": "Get countries matching a currency code or name.",
+                "description": "Get countries located (fully or partly) on a continent.",
                 "consumes": [
                     "application/json"
                 ],
@@ -264,20 +339,32 @@ const docTemplate = `{
                 "tags": [
                     "Countries"
                 ],
-                "summary": "Get countries by currency",
+                "summary": "Get countries by continent",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Currency code or name",
-                        "name": "currency",
+                        "description": "Continent name",
+                        "name": "continent",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
                         "name": "fields",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -290,18 +377,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/demonym/{demonym}": {
+        "/continents": {
             "get": {
-                "description": "Get countries matching a demonym.",
+                "description": "Get every continent with country counts and aggregate population and area.",
                 "consumes": [
                     "application/json"
                 ],
@@ -309,46 +402,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Countries"
-                ],
-                "summary": "Get countries by demonym",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Demonym",
-                        "name": "demonym",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
-                        "name": "fields",
-                        "in": "query"
-                    }
+                    "Regions"
                 ],
+                "summary": "Get all continents",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/v1.Country"
+                                "$ref": "#/definitions/v1.GeoNode"
                             }
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/independent": {
+        "/countries": {
             "get": {
-                "description": "Get countries filtered by independence. Defaults to status=true if not specified.",
+                "description": "Get details of all countries, with optional filters.",
                 "consumes": [
                     "application/json"
                 ],
@@ -358,19 +430,31 @@ const docTemplate = `{
                 "tags": [
                     "Countries"
                 ],
-                "summary": "Get countries by independence status",
+                "summary": "Get all countries",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "true or false. Defaults to 'true'",
-                        "name": "status",
+                        "description": "Filter by independent status (true or false)",
+                        "name": "independent",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
                         "name": "fields",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -386,15 +470,15 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/lang/{language}": {
+        "/countries/{code}": {
             "get": {
-                "description": "Get countries matching a language code or name.",
+                "description": "Get details of a specific country by its code (CCA2 or CCA3).",
                 "consumes": [
                     "application/json"
                 ],
@@ -404,44 +488,53 @@ const docTemplate = `{
                 "tags": [
                     "Countries"
                 ],
-                "summary": "Get countries by language",
+                "summary": "Get country by code",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Language code or name",
-                        "name": "language",
+                        "description": "Country code (CCA2 or CCA3)",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
                         "name": "fields",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/v1.Country"
-                            }
+                            "$ref": "#/definitions/v1.Country"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/name/{name}": {
+        "/currency/{currency}": {
             "get": {
-                "description": "Get countries matching a name query (common or official). Use fullText=true for exact name match.",
+                "description": "Get countries matching a currency code or name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -451,25 +544,31 @@ const docTemplate = `{
                 "tags": [
                     "Countries"
                 ],
-                "summary": "Get countries by name",
+                "summary": "Get countries by currency",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Country name (common or official)",
-                        "name": "name",
+                        "description": "Currency code or name",
+                        "name": "currency",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Exact match for full name (true/false)",
-                        "name": "fullText",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
+                        "name": "fields",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
-                        "name": "fields",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
                         "in": "query"
                     }
                 ],
@@ -486,15 +585,21 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/region/{region}": {
+        "/demonym/{demonym}": {
             "get": {
-                "description": "Get countries matching a region.",
+                "description": "Get countries matching a demonym.",
                 "consumes": [
                     "application/json"
                 ],
@@ -504,20 +609,32 @@ const docTemplate = `{
                 "tags": [
                     "Countries"
                 ],
-                "summary": "Get countries by region",
+                "summary": "Get countries by demonym",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Region name",
-                        "name": "region",
+                        "description": "Demonym",
+                        "name": "demonym",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
                         "name": "fields",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -530,18 +647,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/subregion/{subregion}": {
+        "/groups": {
             "get": {
-                "description": "Get countries matching a subregion.",
+                "description": "Get every country grouping (EU, Schengen, ASEAN, OECD, ...) with its members and the memberships dataset version.",
                 "consumes": [
                     "application/json"
                 ],
@@ -549,38 +672,550 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Countries"
-                ],
-                "summary": "Get countries by subregion",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Subregion name",
-                        "name": "subregion",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
-                        "name": "fields",
-                        "in": "query"
-                    }
+                    "Groups"
                 ],
+                "summary": "Get all country groups",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/v1.Country"
-                            }
+                            "$ref": "#/definitions/v1.GroupCatalog"
                         }
-                    },
-                    "404": {
+                    }
+                }
+            }
+        },
+        "/groups/{id}": {
+            "get": {
+                "description": "Get a country grouping and its member countries (CCA3 codes).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get country group by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID (e.g., EU, SCHENGEN, ASEAN)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Group"
+                        }
+                    },
+                    "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/independent": {
+            "get": {
+                "description": "Get countries filtered by independence. Defaults to status=true if not specified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Get countries by independence status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "true or false. Defaults to 'true'",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.Country"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/lang/{language}": {
+            "get": {
+                "description": "Get countries matching a language code or name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Get countries by language",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Language code or name",
+                        "name": "language",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.Country"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/languages": {
+            "get": {
+                "description": "Get every language found in the dataset with its ISO 639 codes, the countries where it is official and their total population.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Languages"
+                ],
+                "summary": "Get all languages",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.Language"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/languages/{code}": {
+            "get": {
+                "description": "Get a language by its ISO 639-1, ISO 639-2 (terminologic or bibliographic) or ISO 639-3 code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Languages"
+                ],
+                "summary": "Get language by code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Language code (e.g., de, deu, ger)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Language"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/locale/resolve": {
+            "get": {
+                "description": "Resolve a BCP 47 tag or Accept-Language list (with quality weights) into the best-matching country and display language, including the country name localized from its translations. Falls back to the request's Accept-Language header when the accept parameter is omitted. Accept-Language lists with quality weights must be percent-encoded in the query, with ';' sent as %3B; an unencoded ';' is rejected with 400 because Go drops the whole pair.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locale"
+                ],
+                "summary": "Resolve a BCP 47 locale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "BCP 47 tag or Accept-Language list, percent-encoded (e.g., pt-BR,pt%3Bq=0.9,en%3Bq=0.8)",
+                        "name": "accept",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.LocaleResolution"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/name/{name}": {
+            "get": {
+                "description": "Get countries matching a name query (common or official). Use fullText=true for exact name match.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Get countries by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country name (common or official)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exact match for full name (true/false)",
+                        "name": "fullText",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.Country"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/region/{region}": {
+            "get": {
+                "description": "Get countries matching a region.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Get countries by region",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Region name",
+                        "name": "region",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.Country"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/regions": {
+            "get": {
+                "description": "Get every region with its subregions, country counts and aggregate population and area.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Regions"
+                ],
+                "summary": "Get all regions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.Region"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/regions/{region}/subregions": {
+            "get": {
+                "description": "Get the subregions of a region with country counts and aggregate population and area.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Regions"
+                ],
+                "summary": "Get subregions of a region",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Region name",
+                        "name": "region",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.GeoNode"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/subregion/{subregion}": {
+            "get": {
+                "description": "Get countries matching a subregion.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Get countries by subregion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subregion name",
+                        "name": "subregion",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.Country"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
@@ -609,8 +1244,20 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of fields to include in the response",
-                        "name": "fields",
+                        "description": "Comma-separated JSON field paths to include (e.g., name.common, translations.*.common); prefix with - to exclude",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language for localized names (e.g., de, ja); defaults to Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include members of this group (e.g., EU, SCHENGEN)",
+                        "name": "group",
                         "in": "query"
                     }
                 ],
@@ -624,10 +1271,16 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/v1.ErrorResponse"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
@@ -635,6 +1288,78 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "v1.BatchLookup": {
+            "type": "object",
+            "properties": {
+                "alpha": {
+                    "type": "string",
+                    "example": "DE"
+                },
+                "callingcode": {
+                    "type": "string",
+                    "example": "44"
+                },
+                "capital": {
+                    "type": "string",
+                    "example": "Tallinn"
+                },
+                "ccn3": {
+                    "type": "string",
+                    "example": "276"
+                },
+                "continent": {
+                    "type": "string",
+                    "example": "South America"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "demonym": {
+                    "type": "string",
+                    "example": "German"
+                },
+                "fullText": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "lang": {
+                    "type": "string",
+                    "example": "spa"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Japan"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "Europe"
+                },
+                "subregion": {
+                    "type": "string",
+                    "example": "Northern Europe"
+                },
+                "translation": {
+                    "type": "string",
+                    "example": "Saksamaa"
+                }
+            }
+        },
+        "v1.BatchResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "error": {
+                    "$ref": "#/definitions/v1.Problem"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
         "v1.CapitalInfo": {
             "type": "object",
             "properties": {
@@ -832,15 +1557,7 @@ const docTemplate = `{
                 "translations": {
                     "type": "object",
                     "additionalProperties": {
-                        "type": "object",
-                        "properties": {
-                            "common": {
-                                "type": "string"
-                            },
-                            "official": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/v1.Translation"
                     }
                 },
                 "unMember": {
@@ -892,14 +1609,46 @@ const docTemplate = `{
                 }
             }
         },
-        "v1.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Bad request"
-                }
-            }
+        "v1.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "invalid_parameter",
+                "missing_parameter",
+                "body_too_large",
+                "validation_failed",
+                "country_not_found",
+                "language_not_found",
+                "region_not_found",
+                "group_not_found",
+                "locale_not_matched",
+                "route_not_found",
+                "missing_api_key",
+                "invalid_api_key",
+                "api_key_disabled",
+                "route_not_allowed",
+                "rate_limited",
+                "internal_error",
+                "not_ready"
+            ],
+            "x-enum-varnames": [
+                "CodeInvalidParameter",
+                "CodeMissingParameter",
+                "CodeBodyTooLarge",
+                "CodeValidationFailed",
+                "CodeCountryNotFound",
+                "CodeLanguageNotFound",
+                "CodeRegionNotFound",
+                "CodeGroupNotFound",
+                "CodeLocaleNotMatched",
+                "CodeRouteNotFound",
+                "CodeMissingAPIKey",
+                "CodeInvalidAPIKey",
+                "CodeAPIKeyDisabled",
+                "CodeRouteNotAllowed",
+                "CodeRateLimited",
+                "CodeInternalError",
+                "CodeNotReady"
+            ]
         },
         "v1.Flags": {
             "type": "object",
@@ -918,6 +1667,74 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.GeoNode": {
+            "type": "object",
+            "properties": {
+                "area": {
+                    "type": "number",
+                    "example": 1108000
+                },
+                "countryCount": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Western Europe"
+                },
+                "population": {
+                    "type": "integer",
+                    "example": 196000000
+                }
+            }
+        },
+        "v1.Group": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Political and economic union of European member states"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "EU"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "AUT",
+                        "BEL",
+                        "BGR"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "European Union"
+                }
+            }
+        },
+        "v1.GroupCatalog": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.Group"
+                    }
+                },
+                "updated": {
+                    "type": "string",
+                    "example": "2026-01-01"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "2026.1"
+                }
+            }
+        },
         "v1.IDD": {
             "type": "object",
             "properties": {
@@ -937,6 +1754,141 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.InvalidParam": {
+            "type": "object",
+            "properties": {
+                "param": {
+                    "type": "string",
+                    "example": "fields"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "unknown field 'populaton'"
+                },
+                "suggestion": {
+                    "type": "string",
+                    "example": "population"
+                }
+            }
+        },
+        "v1.Language": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "deu"
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "AUT",
+                        "BEL",
+                        "DEU"
+                    ]
+                },
+                "iso639_1": {
+                    "type": "string",
+                    "example": "de"
+                },
+                "iso639_2": {
+                    "type": "string",
+                    "example": "deu"
+                },
+                "iso639_2b": {
+                    "type": "string",
+                    "example": "ger"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "German"
+                },
+                "population": {
+                    "type": "integer",
+                    "example": 100000000
+                }
+            }
+        },
+        "v1.LocaleCandidate": {
+            "type": "object",
+            "properties": {
+                "q": {
+                    "type": "number",
+                    "example": 1
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "pt-BR"
+                }
+            }
+        },
+        "v1.LocaleCountry": {
+            "type": "object",
+            "properties": {
+                "cca2": {
+                    "type": "string",
+                    "example": "BR"
+                },
+                "cca3": {
+                    "type": "string",
+                    "example": "BRA"
+                },
+                "localizedName": {
+                    "$ref": "#/definitions/v1.Name"
+                },
+                "name": {
+                    "$ref": "#/definitions/v1.Name"
+                }
+            }
+        },
+        "v1.LocaleLanguage": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "por"
+                },
+                "iso639_1": {
+                    "type": "string",
+                    "example": "pt"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Portuguese"
+                },
+                "official": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "v1.LocaleResolution": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.LocaleCandidate"
+                    }
+                },
+                "country": {
+                    "$ref": "#/definitions/v1.LocaleCountry"
+                },
+                "input": {
+                    "type": "string",
+                    "example": "pt-BR,pt;q=0.9,en;q=0.8"
+                },
+                "language": {
+                    "$ref": "#/definitions/v1.LocaleLanguage"
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "pt-BR"
+                }
+            }
+        },
         "v1.Maps": {
             "type": "object",
             "properties": {
@@ -950,6 +1902,19 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.Membership": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "EU"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "European Union"
+                }
+            }
+        },
         "v1.Name": {
             "type": "object",
             "properties": {
@@ -975,6 +1940,98 @@ const docTemplate = `{
                     "example": "^\\d{5}(-\\d{4})?$"
                 }
             }
+        },
+        "v1.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ErrorCode"
+                        }
+                    ],
+                    "example": "invalid_parameter"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "invalid boolean value: yes (must be 'true' or 'false')"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/v1/independent"
+                },
+                "invalidParams": {
+                    "description": "InvalidParams lists every rejected parameter of a validation_failed problem.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.InvalidParam"
+                    }
+                },
+                "param": {
+                    "type": "string",
+                    "example": "status"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Invalid parameter"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "https://github.com/DoROAD-AI/gcr/blob/main/ERRORS.md#invalid_parameter"
+                }
+            }
+        },
+        "v1.Region": {
+            "type": "object",
+            "properties": {
+                "area": {
+                    "type": "number",
+                    "example": 1108000
+                },
+                "countryCount": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Western Europe"
+                },
+                "population": {
+                    "type": "integer",
+                    "example": 196000000
+                },
+                "subregions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.GeoNode"
+                    }
+                }
+            }
+        },
+        "v1.Translation": {
+            "type": "object",
+            "properties": {
+                "common": {
+                    "type": "string",
+                    "example": "Deutschland"
+                },
+                "official": {
+                    "type": "string",
+                    "example": "Bundesrepublik Deutschland"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key, required when the server is configured with a keys file",
+            "type": "apiKey",
+            "name": "dapi-key",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,259 @@
+// Command fieldgen generates the reflection-free field accessors used by field projection.
+//
+// It parses the Go files of a package, starts at the given struct type and emits one
+// fieldSchema variable per reachable struct or map type, with accessor closures for every JSON
+// member. Run it through go generate in the package that declares the types:
+//
+//	//go:generate go run ../../internal/fieldgen -type Country -output fields_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	typeName := flag.String("type", "", "root struct type")
+	output := flag.String("output", "", "output file")
+	flag.Parse()
+	if *typeName == "" || *output == "" {
+		log.Fatal("fieldgen: -type and -output are required")
+	}
+
+	pkg, decls, err := parseTypes(".", *output)
+	if err != nil {
+		log.Fatalf("fieldgen: %v", err)
+	}
+
+	g := &generator{decls: decls, emitted: make(map[string]bool)}
+	root := g.schemaFor(ast.NewIdent(*typeName), "")
+	if root == "" {
+		log.Fatalf("fieldgen: %s is not a struct or map type", *typeName)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by fieldgen -type %s; DO NOT EDIT.\n\n", *typeName)
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	out.Write(g.body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatalf("fieldgen: formatting output: %v\n%s", err, out.Bytes())
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatalf("fieldgen: %v", err)
+	}
+}
+
+// parseTypes collects the type declarations of the package in dir, skipping tests and the
+// previous output so a stale file never breaks generation.
+func parseTypes(dir, output string) (string, map[string]ast.Expr, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != output
+	}, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	decls := make(map[string]ast.Expr)
+	var name string
+	for pkgName, pkg := range pkgs {
+		name = pkgName
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					decls[ts.Name.Name] = ts.Type
+				}
+			}
+		}
+	}
+	return name, decls, nil
+}
+
+// generator accumulates the schema declarations.
+type generator struct {
+	decls   map[string]ast.Expr
+	emitted map[string]bool
+	body    bytes.Buffer
+}
+
+// resolve follows a named type to its declaration; other expressions are returned unchanged.
+func (g *generator) resolve(expr ast.Expr) ast.Expr {
+	if ident, ok := expr.(*ast.Ident); ok {
+		if decl, ok := g.decls[ident.Name]; ok {
+			return decl
+		}
+	}
+	return expr
+}
+
+// schemaFor emits the schema of a struct or map type and returns its variable name, or "" for
+// leaf types. fallback names unnamed map types after their field.
+func (g *generator) schemaFor(expr ast.Expr, fallback string) string {
+	var name string
+	if ident, ok := expr.(*ast.Ident); ok {
+		name = lowerFirst(ident.Name) + "Schema"
+	} else {
+		name = fallback + "Schema"
+	}
+
+	switch t := g.resolve(expr).(type) {
+	case *ast.StructType:
+		if !g.emitted[name] {
+			g.emitted[name] = true
+			g.emitStruct(name, types.ExprString(expr), t)
+		}
+		return name
+	case *ast.MapType:
+		if key, ok := t.Key.(*ast.Ident); !ok || key.Name != "string" {
+			return ""
+		}
+		if !g.emitted[name] {
+			g.emitted[name] = true
+			g.emitMap(name, types.ExprString(expr), t, fallback)
+		}
+		return name
+	}
+	return ""
+}
+
+// emitStruct writes the schema of a struct type; accessors receive a pointer to it.
+func (g *generator) emitStruct(name, typeName string, st *ast.StructType) {
+	var members bytes.Buffer
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			continue
+		}
+		for _, fieldName := range field.Names {
+			if !fieldName.IsExported() {
+				continue
+			}
+			jsonName, omitEmpty := jsonTag(field, fieldName.Name)
+			if jsonName == "-" {
+				continue
+			}
+
+			fieldType := field.Type
+			star, isPtr := fieldType.(*ast.StarExpr)
+			if isPtr {
+				fieldType = star.X
+			}
+			child := g.schemaFor(fieldType, strings.TrimSuffix(name, "Schema")+fieldName.Name)
+
+			fmt.Fprintf(&members, "\t{name: %q, get: func(v interface{}) interface{} { ", jsonName)
+			switch {
+			case isPtr:
+				fmt.Fprintf(&members, "if p := v.(*%s).%s; p != nil { return p }; return nil }", typeName, fieldName.Name)
+			case child != "" && g.isStruct(fieldType):
+				fmt.Fprintf(&members, "return &v.(*%s).%s }", typeName, fieldName.Name)
+			default:
+				fmt.Fprintf(&members, "return v.(*%s).%s }", typeName, fieldName.Name)
+			}
+			if omitEmpty {
+				if empty := g.emptyCheck(field.Type); empty != "" {
+					fmt.Fprintf(&members, ", empty: func(v interface{}) bool { return %s }", empty)
+				}
+			}
+			if child != "" {
+				fmt.Fprintf(&members, ", schema: %s", child)
+			}
+			members.WriteString("},\n")
+		}
+	}
+
+	fmt.Fprintf(&g.body, "// %s projects %s.\n", name, typeName)
+	fmt.Fprintf(&g.body, "var %s = structSchema([]*fieldMember{\n%s})\n\n", name, members.Bytes())
+}
+
+// emitMap writes the schema of a map type keyed by strings.
+func (g *generator) emitMap(name, typeName string, mt *ast.MapType, fallback string) {
+	child := g.schemaFor(mt.Value, fallback+"Value")
+	lookup := "x, ok := v.(%s)[key]; return x, ok"
+	if child != "" && g.isStruct(mt.Value) {
+		lookup = "x, ok := v.(%s)[key]; return &x, ok"
+	}
+
+	fmt.Fprintf(&g.body, "// %s projects %s.\n", name, typeName)
+	fmt.Fprintf(&g.body, "var %s = &fieldSchema{\n", name)
+	fmt.Fprintf(&g.body, "\tkeys: func(v interface{}) []string { m := v.(%s); keys := make([]string, 0, len(m)); for k := range m { keys = append(keys, k) }; return keys },\n", typeName)
+	fmt.Fprintf(&g.body, "\tlookup: func(v interface{}, key string) (interface{}, bool) { "+lookup+" },\n", typeName)
+	if child != "" {
+		fmt.Fprintf(&g.body, "\telem: %s,\n", child)
+	}
+	g.body.WriteString("}\n\n")
+}
+
+// isStruct reports whether expr resolves to a struct type.
+func (g *generator) isStruct(expr ast.Expr) bool {
+	_, ok := g.resolve(expr).(*ast.StructType)
+	return ok
+}
+
+// emptyCheck returns the expression testing v for emptiness as encoding/json's omitempty does,
+// or "" for structs, which are never omitted.
+func (g *generator) emptyCheck(expr ast.Expr) string {
+	if _, ok := expr.(*ast.StarExpr); ok {
+		return "v == nil"
+	}
+	typeName := types.ExprString(expr)
+	switch t := g.resolve(expr).(type) {
+	case *ast.ArrayType, *ast.MapType:
+		return fmt.Sprintf("len(v.(%s)) == 0", typeName)
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return fmt.Sprintf("v.(%s) == \"\"", typeName)
+		case "bool":
+			return fmt.Sprintf("!v.(%s)", typeName)
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+			return fmt.Sprintf("v.(%s) == 0", typeName)
+		}
+	}
+	return ""
+}
+
+// jsonTag returns the JSON name of a field and whether it has the omitempty option.
+func jsonTag(field *ast.Field, goName string) (string, bool) {
+	if field.Tag == nil {
+		return goName, false
+	}
+	tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("json")
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = goName
+	}
+	return name, strings.Contains(","+opts+",", ",omitempty,")
+}
+
+// lowerFirst lower-cases the leading run of upper-case letters, so IDD becomes idd and
+// CoatOfArms becomes coatOfArms.
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
@@ -0,0 +1,44 @@
+// logging.go builds the application's log/slog logger from the configured level and format.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"strings"
+)
+
+// ParseLevel converts a level name (debug, info, warn, error) to a slog.Level.
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(name))); err != nil {
+		return level, fmt.Errorf("unknown log level %q", name)
+	}
+	return level, nil
+}
+
+// New returns a logger writing JSON or text records at or above level to w.
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	switch format {
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+// SetDefault installs logger as the slog default and routes the standard log package through it,
+// so log.Printf calls from dependencies end up as structured records too.
+func SetDefault(logger *slog.Logger) {
+	slog.SetDefault(logger)
+	log.SetFlags(0)
+}
@@ -2,15 +2,30 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"google.golang.org/grpc"
 
+	"github.com/DoROAD-AI/gcr/api/compat"
+	"github.com/DoROAD-AI/gcr/api/middleware"
+	"github.com/DoROAD-AI/gcr/api/rpc"
 	v1 "github.com/DoROAD-AI/gcr/api/v1"
+	"github.com/DoROAD-AI/gcr/config"
 	"github.com/DoROAD-AI/gcr/docs"
+	"github.com/DoROAD-AI/gcr/logging"
+	"github.com/DoROAD-AI/gcr/telemetry"
 	"github.com/gin-contrib/cors"
 )
 
@@ -26,47 +41,164 @@ import (
 // @BasePath      /v1
 // @schemes       https http
 
-func getHost() string {
-	env := os.Getenv("ATLAS_ENV")
-	switch env {
-	case "production":
-		return "atlas.doroad.dev"
-	case "test":
-		return "atlas-test.doroad.dev"
-	case "dev":
-		return "atlas-dev.doroad.dev"
-	case "core":
-		return "gcr.doroad.dev"
-	default:
-		return "localhost:3101"
-	}
-}
+// @securityDefinitions.apikey ApiKeyAuth
+// @in                         header
+// @name                       dapi-key
+// @description                API key, required when the server is configured with a keys file
+// @security                   ApiKeyAuth
 
 func main() {
-	// Set Gin mode based on environment
-	env := os.Getenv("ATLAS_ENV")
-	if env == "production" {
+	configFile := flag.String("config", os.Getenv("ATLAS_CONFIG"), "Path to a YAML or TOML config file")
+	flag.Parse()
+
+	// Load configuration from file and environment
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Structured logging for the application, dependencies and access logs
+	logger, err := logging.New(os.Stderr, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		log.Fatalf("Failed to initialize logging: %v", err)
+	}
+	logging.SetDefault(logger)
+
+	// Gin's debug output is only wanted at debug log level
+	if cfg.Log.Level != "debug" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	// Load country data from JSON
-	if err := v1.LoadCountriesSafe("data/countries.json"); err != nil {
-		log.Fatalf("Failed to initialize country data: %v", err)
+	if err := v1.LoadCountriesSafe(cfg.Data.Countries); err != nil {
+		fatal("Failed to initialize country data", err)
+	}
+
+	// Load ISO 639 language mapping from JSON
+	if err := v1.LoadLanguagesSafe(cfg.Data.Languages); err != nil {
+		fatal("Failed to initialize language data", err)
+	}
+
+	// Load country groupings from JSON
+	if err := v1.LoadGroupsSafe(cfg.Data.Groups); err != nil {
+		fatal("Failed to initialize group data", err)
+	}
+
+	// Set up OpenTelemetry tracing (spans are no-ops when no exporter is configured)
+	shutdownTracing, err := telemetry.SetupTracing(context.Background(), telemetry.TracingOptions{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		fatal("Failed to initialize tracing", err)
 	}
 
-	// Create Gin router with default middleware
-	router := gin.Default()
+	// Error responses use RFC 7807 problem details unless the legacy shape is configured
+	v1.LegacyErrors = cfg.Errors.Format == "legacy"
+
+	// Create Gin router; request ids, access logs and panic recovery replace gin's defaults
+	router := gin.New()
+	router.NoRoute(v1.RouteNotFound)
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		fatal("Failed to set trusted proxies", err)
+	}
+
+	// Request ids, access logs, Prometheus instrumentation, tracing and compression for every route. Recovery
+	// comes last so the outer middleware observe the 500 it writes.
+	metrics := middleware.NewMetrics()
+	router.Use(
+		middleware.RequestID(),
+		middleware.AccessLog(logger),
+		metrics.Middleware(),
+		middleware.Tracing(),
+		middleware.Compress(),
+		middleware.Recovery(logger),
+	)
 
 	// Enable CORS
-	router.Use(cors.Default())
+	corsConfig := cors.Config{
+		AllowMethods: cfg.CORS.AllowMethods,
+		AllowHeaders: cfg.CORS.AllowHeaders,
+		ExposeHeaders: []string{
+			middleware.RequestIDHeader, "ETag", v1.NotFoundHeader,
+			"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After",
+		},
+		MaxAge: 12 * time.Hour,
+	}
+	if cfg.CORS.AllowsAllOrigins() {
+		corsConfig.AllowAllOrigins = true
+	} else {
+		corsConfig.AllowOrigins = cfg.CORS.AllowOrigins
+	}
+	router.Use(cors.New(corsConfig))
+
+	// Set Swagger host and schemes from configuration
+	docs.SwaggerInfo.Host = cfg.Swagger.Host
+	docs.SwaggerInfo.Schemes = cfg.Swagger.Schemes
+
+	// Authentication and rate limits shared by the /v1, /v3.1 and /graphql routes and gRPC
+	var protected []gin.HandlerFunc
+	var guard rpc.Guard
 
-	// Dynamically set Swagger host
-	docs.SwaggerInfo.Host = getHost()
+	// Optional API key authentication, enabled by configuring a keys file
+	var keyStore *middleware.KeyStore
+	if keysFile := cfg.Auth.KeysFile; keysFile != "" {
+		keyStore, err = middleware.NewKeyStore(keysFile)
+		if err != nil {
+			fatal("Failed to initialize API keys", err)
+		}
+		guard.Keys = keyStore
+
+		// Reload the keys file on SIGHUP without restarting
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		go func() {
+			for range hup {
+				if err := keyStore.Reload(); err != nil {
+					slog.Error("Failed to reload API keys", "error", err)
+					continue
+				}
+				slog.Info("Reloaded API keys", "file", keysFile)
+			}
+		}()
+	}
+
+	// Optional rate limiting, enabled by configuring a tiers file. It runs before authentication so
+	// rejected requests are charged to the client IP and key guessing is throttled.
+	if limitsFile := cfg.Auth.RateLimitsFile; limitsFile != "" {
+		limits, err := middleware.LoadRateLimitConfig(limitsFile)
+		if err != nil {
+			fatal("Failed to initialize rate limits", err)
+		}
+		store := middleware.NewMemoryStore()
+		protected = append(protected, middleware.RateLimit(limits, store, keyStore))
+		guard.Limits, guard.Store = &limits, store
+	}
+	if keyStore != nil {
+		protected = append(protected, middleware.APIKeyAuth(keyStore))
+	}
 
 	// v1 routes
-	v1Group := router.Group("/v1")
+	v1Group := router.Group("/v1", protected...)
+
+	// Strict validation where configured or requested with strict=true
+	v1.MaxCodes = cfg.Validation.MaxCodes
+	v1.MaxBatchLookups = cfg.Validation.MaxBatch
+	v1Group.Use(middleware.StrictValidation(middleware.StrictOptions{
+		Default:     cfg.Validation.Strict,
+		Routes:      cfg.Validation.RouteMap(),
+		RoutePrefix: "/v1",
+	}))
+
+	// ETags, Last-Modified and Cache-Control; after auth so a 304 is never served to rejected requests
+	v1Group.Use(middleware.HTTPCache(middleware.CacheOptions{
+		Default: cfg.Cache.Default,
+		Routes:  cfg.Cache.RouteMap(),
+	}))
+
 	{
-		// restcountries.com v3.1 compatible routes
+		// Routes modelled on restcountries.com v3.1; /v3.1 reproduces its exact behavior
 		v1Group.GET("/all", v1.GetCountries)
 		v1Group.GET("/countries", v1.GetCountries)
 		v1Group.GET("/countries/:code", v1.GetCountryByCode)
@@ -78,23 +210,132 @@ func main() {
 		v1Group.GET("/capital/:capital", v1.GetCountriesByCapital)
 		v1Group.GET("/region/:region", v1.GetCountriesByRegion)
 		v1Group.GET("/subregion/:subregion", v1.GetCountriesBySubregion)
+		v1Group.GET("/continent/:continent", v1.GetCountriesByContinent)
 		v1Group.GET("/translation/:translation", v1.GetCountriesByTranslation)
 		v1Group.GET("/independent", v1.GetCountriesByIndependence)
 		v1Group.GET("/alpha/:code", v1.GetCountryByAlphaCode)
+		v1Group.GET("/alpha/:code/memberships", v1.GetCountryMemberships)
 		v1Group.GET("/ccn3/:code", v1.GetCountryByCCN3)
 		// New route for calling code
 		v1Group.GET("/callingcode/:callingcode", v1.GetCountriesByCallingCode)
+
+		// Language registry
+		v1Group.GET("/languages", v1.GetLanguages)
+		v1Group.GET("/languages/:code", v1.GetLanguageByCode)
+
+		// Region, subregion and continent catalogs
+		v1Group.GET("/regions", v1.GetRegions)
+		v1Group.GET("/regions/:region/subregions", v1.GetSubregionsByRegion)
+		v1Group.GET("/continents", v1.GetContinents)
+
+		// Country groupings
+		v1Group.GET("/groups", v1.GetGroups)
+		v1Group.GET("/groups/:id", v1.GetGroupByID)
+
+		// Locale resolution
+		v1Group.GET("/locale/resolve", v1.ResolveLocale)
+
+		// Batch lookups of mixed kinds
+		v1Group.POST("/batch", v1.PostBatch)
 	}
 
+	// restcountries.com v3.1 shapes and status codes, for clients switching over unchanged
+	if cfg.Compat.Enabled {
+		compatGroup := router.Group("/v3.1", protected...)
+		compatGroup.Use(middleware.HTTPCache(middleware.CacheOptions{
+			Default: cfg.Cache.Default,
+			Routes:  cfg.Cache.RouteMap(),
+		}))
+		compat.Register(compatGroup)
+	}
+
+	// GraphQL over the same dataset, with GraphiQL for browsers
+	if cfg.GraphQL.Enabled {
+		v1.GraphiQL = cfg.GraphQL.GraphiQL
+		v1.GraphQLMaxDepth = cfg.GraphQL.MaxDepth
+		v1.GraphQLMaxComplexity = cfg.GraphQL.MaxComplexity
+		graphqlGroup := router.Group("/graphql", protected...)
+		graphqlGroup.GET("", v1.GraphQL)
+		graphqlGroup.POST("", v1.GraphQL)
+	}
+
+	// Health, build information and metrics, outside /v1 so probes bypass authentication and rate limits
+	router.GET("/healthz", v1.Healthz)
+	router.GET("/readyz", v1.Readyz)
+	router.GET("/version", v1.GetVersion)
+	router.GET("/metrics", metrics.Handler())
+
 	// Swagger documentation endpoint
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// Determine port, default to 3101
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "3101"
+	// Start server
+	server := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           router,
+		ReadTimeout:       time.Duration(cfg.Server.ReadTimeout),
+		ReadHeaderTimeout: time.Duration(cfg.Server.ReadHeaderTimeout),
+		WriteTimeout:      time.Duration(cfg.Server.WriteTimeout),
+		IdleTimeout:       time.Duration(cfg.Server.IdleTimeout),
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+	}
+
+	// Catch SIGINT/SIGTERM before any listener starts, so a signal arriving during startup is a
+	// graceful shutdown rather than the default abrupt exit
+	stop, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	serverErr := make(chan error, 2)
+	go func() {
+		slog.Info("Listening", "addr", cfg.Server.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	// Optional gRPC server on its own port, serving the same dataset
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Addr != "" {
+		listener, err := net.Listen("tcp", cfg.GRPC.Addr)
+		if err != nil {
+			fatal("Failed to listen for gRPC", err)
+		}
+		grpcServer = rpc.NewServer(logger, guard)
+		go func() {
+			slog.Info("Listening for gRPC", "addr", cfg.GRPC.Addr)
+			serverErr <- grpcServer.Serve(listener)
+		}()
 	}
 
-	// Start server
-	router.Run(":" + port)
+	// Wait for SIGINT/SIGTERM or a listener failure
+	select {
+	case err := <-serverErr:
+		fatal("Server failed", err)
+	case <-stop.Done():
+		slog.Info("Shutting down, draining in-flight requests", "timeout", cfg.Server.ShutdownTimeout.String())
+	}
+
+	// Stop accepting connections and let in-flight requests finish within the deadline
+	ctx, cancelShutdown := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeout))
+	defer cancelShutdown()
+	if grpcServer != nil {
+		go func() {
+			// GracefulStop waits for streams without a deadline; stop them once ctx expires
+			<-ctx.Done()
+			grpcServer.Stop()
+		}()
+	}
+	if err := server.Shutdown(ctx); err != nil {
+		fatal("Graceful shutdown failed", err)
+	}
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+	slog.Info("Server stopped")
+}
+
+// fatal logs err at error level and exits with a non-zero status.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,77 @@
+// tracing.go sets up the OpenTelemetry tracer provider and W3C trace context propagation.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/DoROAD-AI/gcr/version"
+)
+
+// ServiceName is reported as service.name on every span.
+const ServiceName = "gcr"
+
+// TracingOptions selects and configures the span exporter.
+type TracingOptions struct {
+	// Exporter is "otlp" (OTLP over HTTP), "stdout" or empty to disable tracing.
+	Exporter string
+	// Endpoint overrides the OTLP endpoint URL; empty uses OTEL_EXPORTER_OTLP_* env vars or localhost:4318.
+	Endpoint string
+	// SampleRatio is the fraction of new traces to sample; incoming sampled traces are always continued.
+	SampleRatio float64
+}
+
+// SetupTracing installs a global tracer provider and the W3C trace context propagator. The
+// returned function flushes pending spans and must be called on shutdown. With tracing disabled
+// only the propagator is installed and spans are no-ops.
+func SetupTracing(ctx context.Context, opts TracingOptions) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch opts.Exporter {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		var clientOpts []otlptracehttp.Option
+		if opts.Endpoint != "" {
+			clientOpts = append(clientOpts, otlptracehttp.WithEndpointURL(opts.Endpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, clientOpts...)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", opts.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", ServiceName),
+		attribute.String("service.version", version.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
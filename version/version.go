@@ -0,0 +1,13 @@
+// version.go holds build metadata injected at link time, e.g.
+//
+//	go build -ldflags "-X github.com/DoROAD-AI/gcr/version.Version=v1.2.0 -X github.com/DoROAD-AI/gcr/version.Commit=$(git rev-parse HEAD)"
+package version
+
+// Version is the release version of the build.
+var Version = "dev"
+
+// Commit is the git commit the binary was built from.
+var Commit = "unknown"
+
+// BuildDate is the UTC build timestamp in RFC 3339 format.
+var BuildDate = "unknown"